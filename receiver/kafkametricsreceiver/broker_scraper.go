@@ -0,0 +1,96 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+const (
+	brokersScraperName = "brokers"
+)
+
+type brokerScraper struct {
+	client         sarama.Client
+	logger         *zap.Logger
+	saramaConfig   *sarama.Config
+	config         Config
+	mb             *metadata.MetricsBuilder
+	tracerProvider trace.TracerProvider
+}
+
+func (s *brokerScraper) Name() string {
+	return brokersScraperName
+}
+
+func (s *brokerScraper) shutdown(context.Context) error {
+	if s.client == nil || s.client.Closed() {
+		return nil
+	}
+	return s.client.Close()
+}
+
+func (s *brokerScraper) start(context.Context, component.Host) error {
+	if s.client != nil {
+		return nil
+	}
+	client, err := newSaramaClient(s.config.Brokers, s.saramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	s.client = newTracedClient(client, s.tracerProvider, s.config.Brokers)
+	return nil
+}
+
+func (s *brokerScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	ctx, span := startScrapeSpan(ctx, s.tracerProvider)
+	defer span.End()
+	if cs, ok := s.client.(contextSetter); ok {
+		cs.withContext(ctx)
+	}
+
+	brokers := s.client.Brokers()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	s.mb.RecordKafkaBrokersDataPoint(now, int64(len(brokers)))
+	return s.mb.Emit(), nil
+}
+
+func createBrokerScraper(_ context.Context, cfg Config, buildInfo component.BuildInfo, saramaConfig *sarama.Config, logger *zap.Logger, _ *metadataCache, tracerProvider trace.TracerProvider) (scraperhelper.Scraper, error) {
+	s := brokerScraper{
+		logger:         logger,
+		saramaConfig:   saramaConfig,
+		config:         cfg,
+		mb:             metadata.NewMetricsBuilder(cfg.Metrics, buildInfo),
+		tracerProvider: tracerProvider,
+	}
+	return scraperhelper.NewScraper(
+		s.Name(),
+		s.scrape,
+		scraperhelper.WithStart(s.start),
+		scraperhelper.WithShutdown(s.shutdown),
+	)
+}
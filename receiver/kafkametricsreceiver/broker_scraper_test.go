@@ -0,0 +1,92 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+func TestBrokerShutdown(t *testing.T) {
+	client := newMockClient()
+	client.closed = false
+	client.close = nil
+	client.Mock.
+		On("Close").Return(nil).
+		On("Closed").Return(false)
+	scraper := brokerScraper{
+		client: client,
+	}
+	_ = scraper.shutdown(context.Background())
+	client.AssertExpectations(t)
+}
+
+func TestBrokerScraper_Name(t *testing.T) {
+	s := brokerScraper{}
+	assert.Equal(t, s.Name(), brokersScraperName)
+}
+
+func TestBrokerScraper_createBrokerScraper(t *testing.T) {
+	sc := sarama.NewConfig()
+	newSaramaClient = mockNewSaramaClient
+	bs, err := createBrokerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, bs)
+}
+
+func TestBrokerScraperStart(t *testing.T) {
+	newSaramaClient = mockNewSaramaClient
+	sc := sarama.NewConfig()
+	bs, err := createBrokerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, bs)
+	err = bs.Start(context.Background(), nil)
+	assert.NoError(t, err)
+}
+
+func TestBrokerScraperStart_handlesError(t *testing.T) {
+	newSaramaClient = func(addrs []string, conf *sarama.Config) (sarama.Client, error) {
+		return nil, fmt.Errorf("new client failed")
+	}
+	sc := sarama.NewConfig()
+	bs, err := createBrokerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, bs)
+	err = bs.Start(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestBrokerScraper_scrape(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	client := newMockClient()
+	client.brokers = []*sarama.Broker{sarama.NewBroker("broker1"), sarama.NewBroker("broker2")}
+	bs := brokerScraper{
+		client: client,
+		logger: zap.NewNop(),
+		config: *config,
+		mb:     metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := bs.scrape(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, md)
+}
@@ -0,0 +1,130 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+// ClientType selects the protocol the receiver uses to talk to the cluster.
+type ClientType string
+
+const (
+	// ClientTypeSarama scrapes over the native Kafka protocol (the default).
+	ClientTypeSarama ClientType = "sarama"
+	// ClientTypeREST scrapes a Confluent-compatible Kafka REST API, for
+	// clusters (MSK, Confluent Cloud, Redpanda Console) whose brokers are not
+	// directly reachable from the collector.
+	ClientTypeREST ClientType = "rest"
+)
+
+// RESTClientConfig configures the Confluent-compatible REST API backend.
+type RESTClientConfig struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// ClusterID is the Kafka cluster id to scrape, as returned by GET /v3/clusters.
+	// If empty, the receiver resolves it by calling that endpoint and using the
+	// first cluster returned.
+	ClusterID string `mapstructure:"cluster_id"`
+
+	// Username and Password configure HTTP basic authentication against the REST endpoint.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// BearerToken configures bearer token authentication against the REST endpoint.
+	// When set, it takes precedence over Username/Password.
+	BearerToken string `mapstructure:"bearer_token"`
+}
+
+// Config represents user settings for kafkametricsreceiver
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+
+	// The list of kafka brokers (default localhost:9092)
+	Brokers []string `mapstructure:"brokers"`
+
+	// ProtocolVersion is the version of the Kafka protocol to use. (default "2.0.0")
+	ProtocolVersion string `mapstructure:"protocol_version"`
+
+	// TopicMatch is the regex used to match topics to scrape (default ".*")
+	TopicMatch string `mapstructure:"topic_match"`
+
+	// GroupMatch is the regex used to match consumer groups to scrape (default ".*")
+	GroupMatch string `mapstructure:"group_match"`
+
+	// Authentication data
+	Authentication kafkaexporter.Authentication `mapstructure:"auth"`
+
+	// ClientID is the id associated with the consumer that reads from topics in kafka.
+	ClientID string `mapstructure:"client_id"`
+
+	// TLS configuration used to communicate with brokers.
+	TLSClientSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Metrics allows customizing scraped metrics representation.
+	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
+
+	// Scrapers is the list of scrapers enabled for the receiver, currently supports
+	// "brokers", "topics", "consumers".
+	Scrapers []string `mapstructure:"scrapers"`
+
+	// MaxLagTimeProbesPerScrape caps how many record-timestamp probes the consumer
+	// scraper will issue in a single scrape (up to two per (group, topic,
+	// partition): committed and head), to bound the extra network calls
+	// kafka.consumer_group.lag_time costs. Lookups served from the cache are not
+	// counted.
+	// A value <= 0 disables kafka.consumer_group.lag_time entirely. (default 1000)
+	MaxLagTimeProbesPerScrape int `mapstructure:"max_lag_time_probes_per_scrape"`
+
+	// LagTimeCacheTTL is how long a resolved (topic, partition, offset) -> timestamp
+	// lookup is considered valid. Entries older than the TTL are re-probed rather
+	// than reused across scrapes. (default 10m)
+	LagTimeCacheTTL time.Duration `mapstructure:"lag_time_cache_ttl"`
+
+	// MetadataRefreshInterval controls how long topic partition/replica metadata
+	// fetched from the cluster is reused across scrapes before being refreshed.
+	// Fast-changing data (offsets, consumer group offsets) is always fetched live
+	// regardless of this setting. (default 5m)
+	MetadataRefreshInterval time.Duration `mapstructure:"metadata_refresh_interval"`
+
+	// Client selects the protocol used to reach the cluster: "sarama" (native
+	// Kafka protocol, default) or "rest" (Confluent-compatible REST API).
+	Client ClientType `mapstructure:"client"`
+
+	// REST configures the "rest" client. Ignored when Client is "sarama".
+	REST RESTClientConfig `mapstructure:"rest"`
+}
+
+// Validate checks the receiver configuration is self consistent.
+func (cfg *Config) Validate() error {
+	switch cfg.Client {
+	case "", ClientTypeSarama:
+	case ClientTypeREST:
+		if cfg.REST.Endpoint == "" {
+			return fmt.Errorf("rest.endpoint must be specified when client is %q", ClientTypeREST)
+		}
+	default:
+		return fmt.Errorf("client must be %q or %q, got %q", ClientTypeSarama, ClientTypeREST, cfg.Client)
+	}
+	return nil
+}
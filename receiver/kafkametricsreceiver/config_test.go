@@ -0,0 +1,41 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate_defaultClient(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_restRequiresEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Client = ClientTypeREST
+	assert.Error(t, cfg.Validate())
+
+	cfg.REST.Endpoint = "http://localhost:8082"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_rejectsUnknownClient(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Client = "graphql"
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,377 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+const (
+	consumersScraperName = "consumers"
+)
+
+type consumerScraper struct {
+	client       sarama.Client
+	logger       *zap.Logger
+	clusterAdmin sarama.ClusterAdmin
+	topicFilter  *regexp.Regexp
+	groupFilter  *regexp.Regexp
+	saramaConfig *sarama.Config
+	config       Config
+	mb           *metadata.MetricsBuilder
+
+	// lagTimeCache memoizes topic/partition/offset -> record timestamp lookups
+	// across scrape cycles so a partition with no new commits does no extra I/O.
+	lagTimeCache map[lagTimeCacheKey]lagTimeCacheEntry
+	// lagTimeProbes is reset at the start of every scrape and bounds how many
+	// record-timestamp lookups that scrape is allowed to issue.
+	lagTimeProbes int
+	// fetchRecordTimestampFn resolves the timestamp of the record at a given
+	// topic/partition/offset. Defaults to a broker Fetch lookup; overridable in
+	// tests.
+	fetchRecordTimestampFn func(topic string, partition int32, offset int64) (time.Time, error)
+
+	// metadataCache memoizes topic partition metadata across scrapes. Left nil
+	// by test fixtures that construct consumerScraper{} literals directly, in
+	// which case each scrape uses a fresh cache that lasts only for that scrape.
+	metadataCache *metadataCache
+
+	// tracerProvider, when non-nil, causes ensureConnected to wrap the sarama
+	// client/cluster admin in a tracedClient/tracedClusterAdmin so their calls
+	// are recorded as spans under this scraper's per-scrape
+	// kafkametricsreceiver.scrape span.
+	tracerProvider trace.TracerProvider
+}
+
+type lagTimeCacheKey struct {
+	topic     string
+	partition int32
+	offset    int64
+}
+
+type lagTimeCacheEntry struct {
+	timestamp time.Time
+	cachedAt  time.Time
+}
+
+func (s *consumerScraper) Name() string {
+	return consumersScraperName
+}
+
+// start eagerly creates the sarama client/cluster admin, if they haven't
+// already been created (e.g. by a test fixture).
+func (s *consumerScraper) start(context.Context, component.Host) error {
+	return s.ensureConnected()
+}
+
+func (s *consumerScraper) shutdown(context.Context) error {
+	if s.client == nil || s.client.Closed() {
+		return nil
+	}
+	return s.client.Close()
+}
+
+func (s *consumerScraper) ensureConnected() error {
+	if s.client == nil {
+		client, err := newSaramaClient(s.config.Brokers, s.saramaConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		s.client = newTracedClient(client, s.tracerProvider, s.config.Brokers)
+	}
+	if s.clusterAdmin == nil {
+		clusterAdmin, err := newClusterAdmin(s.config.Brokers, s.saramaConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create cluster admin: %w", err)
+		}
+		s.clusterAdmin = newTracedClusterAdmin(clusterAdmin, s.tracerProvider, s.config.Brokers)
+	}
+	return nil
+}
+
+// scrape collects consumer group offset lag and, for each group, the group's
+// state, member count and per-member assigned-partition count.
+func (s *consumerScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if err := s.ensureConnected(); err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	ctx, span := startScrapeSpan(ctx, s.tracerProvider)
+	defer span.End()
+	if cs, ok := s.client.(contextSetter); ok {
+		cs.withContext(ctx)
+	}
+	if cs, ok := s.clusterAdmin.(contextSetter); ok {
+		cs.withContext(ctx)
+	}
+
+	topics, err := s.clusterAdmin.ListTopics()
+	if err != nil {
+		s.logger.Error("Error listing topics", zap.Error(err))
+		return pmetric.NewMetrics(), err
+	}
+
+	matchedTopics := map[string]bool{}
+	for topic := range topics {
+		if s.topicFilter.MatchString(topic) {
+			matchedTopics[topic] = true
+		}
+	}
+
+	groups, err := s.clusterAdmin.ListConsumerGroups()
+	if err != nil {
+		s.logger.Error("Error listing consumer groups", zap.Error(err))
+		return pmetric.NewMetrics(), err
+	}
+
+	var matchedGroups []string
+	for group := range groups {
+		if s.groupFilter.MatchString(group) {
+			matchedGroups = append(matchedGroups, group)
+		}
+	}
+
+	groupDescriptions, err := s.clusterAdmin.DescribeConsumerGroups(matchedGroups)
+	if err != nil {
+		s.logger.Error("Error describing consumer groups", zap.Error(err))
+		return pmetric.NewMetrics(), err
+	}
+
+	nowTime := time.Now()
+	now := pcommon.NewTimestampFromTime(nowTime)
+	var scrapeError error
+	s.lagTimeProbes = 0
+
+	for _, groupDescription := range groupDescriptions {
+		if groupDescription.Err != sarama.ErrNoError {
+			scrapeError = multierr.Append(scrapeError, fmt.Errorf("failed to describe group %s: %w", groupDescription.GroupId, groupDescription.Err))
+			continue
+		}
+		s.mb.RecordKafkaConsumerGroupStateDataPoint(now, 1, groupDescription.GroupId, groupDescription.State)
+		s.mb.RecordKafkaConsumerGroupMembersDataPoint(now, int64(len(groupDescription.Members)), groupDescription.GroupId)
+
+		for _, member := range groupDescription.Members {
+			var partitionCount int64
+			if assignment, assignErr := member.GetMemberAssignment(); assignErr == nil {
+				for topic, partitions := range assignment.Topics {
+					if matchedTopics[topic] {
+						partitionCount += int64(len(partitions))
+					}
+				}
+			} else {
+				s.logger.Warn("Error decoding group member assignment", zap.Error(assignErr), zap.String("group", groupDescription.GroupId))
+			}
+			s.mb.RecordKafkaConsumerGroupMemberAssignedPartitionsDataPoint(
+				now,
+				partitionCount,
+				groupDescription.GroupId,
+				member.ClientId,
+				member.ClientHost,
+				member.MemberId,
+			)
+		}
+	}
+
+	cache := s.cache()
+	for _, group := range matchedGroups {
+		topicPartitions := map[string][]int32{}
+		for topic := range matchedTopics {
+			partitions, partErr := cache.partitions(s.client, topic, nowTime)
+			if partErr != nil {
+				scrapeError = multierr.Append(scrapeError, partErr)
+				continue
+			}
+			topicPartitions[topic] = partitions
+		}
+
+		consumerGroupOffsets, offsetErr := s.clusterAdmin.ListConsumerGroupOffsets(group, topicPartitions)
+		if offsetErr != nil {
+			scrapeError = multierr.Append(scrapeError, offsetErr)
+			continue
+		}
+
+		for topic, partitions := range consumerGroupOffsets.Blocks {
+			var offsetSum, lagSum int64
+			for partition, block := range partitions {
+				if block.Err != sarama.ErrNoError {
+					scrapeError = multierr.Append(scrapeError, fmt.Errorf("failed to retrieve offset for group %s, topic %s, partition %d: %w", group, topic, partition, block.Err))
+					continue
+				}
+				hwm, hwmErr := s.client.GetOffset(topic, partition, sarama.OffsetNewest)
+				if hwmErr != nil {
+					scrapeError = multierr.Append(scrapeError, hwmErr)
+					if isStaleMetadataErr(hwmErr) {
+						cache.invalidate(topic)
+					}
+					continue
+				}
+				s.mb.RecordKafkaConsumerGroupOffsetDataPoint(now, block.Offset, group, topic, int64(partition))
+				s.mb.RecordKafkaConsumerGroupLagDataPoint(now, hwm-block.Offset, group, topic, int64(partition))
+				offsetSum += block.Offset
+				lagSum += hwm - block.Offset
+
+				if lagMillis, ok := s.lagTimeMillis(topic, partition, block.Offset, hwm, nowTime); ok {
+					s.mb.RecordKafkaConsumerGroupLagTimeDataPoint(now, lagMillis, group, topic, int64(partition))
+				}
+			}
+			s.mb.RecordKafkaConsumerGroupOffsetSumDataPoint(now, offsetSum, group, topic)
+			s.mb.RecordKafkaConsumerGroupLagSumDataPoint(now, lagSum, group, topic)
+		}
+	}
+
+	return s.mb.Emit(), scrapeError
+}
+
+// lagTimeMillis returns the Burrow-style time lag, in milliseconds, between
+// the committed offset and the partition head, or false if it could not be
+// resolved within this scrape's probe budget. When the committed offset has
+// caught up to the head it reports zero lag without probing. When
+// MaxLagTimeProbesPerScrape is <= 0 it always returns false.
+func (s *consumerScraper) lagTimeMillis(topic string, partition int32, committedOffset, hwm int64, now time.Time) (int64, bool) {
+	if s.config.MaxLagTimeProbesPerScrape <= 0 {
+		return 0, false
+	}
+	if committedOffset >= hwm {
+		return 0, true
+	}
+
+	committedTS, ok := s.timestampFor(topic, partition, committedOffset, now)
+	if !ok {
+		return 0, false
+	}
+	newestTS, ok := s.timestampFor(topic, partition, hwm-1, now)
+	if !ok {
+		return 0, false
+	}
+	return newestTS.Sub(committedTS).Milliseconds(), true
+}
+
+// timestampFor resolves the timestamp of the record at topic/partition/offset,
+// serving from lagTimeCache when the cached entry is within LagTimeCacheTTL and
+// otherwise issuing at most MaxLagTimeProbesPerScrape new probes per scrape.
+func (s *consumerScraper) timestampFor(topic string, partition int32, offset int64, now time.Time) (time.Time, bool) {
+	if s.config.MaxLagTimeProbesPerScrape <= 0 {
+		return time.Time{}, false
+	}
+
+	key := lagTimeCacheKey{topic: topic, partition: partition, offset: offset}
+	if entry, ok := s.lagTimeCache[key]; ok && now.Sub(entry.cachedAt) < s.config.LagTimeCacheTTL {
+		return entry.timestamp, true
+	}
+
+	if s.lagTimeProbes >= s.config.MaxLagTimeProbesPerScrape {
+		return time.Time{}, false
+	}
+
+	s.lagTimeProbes++
+	ts, err := s.fetchRecordTimestamp(topic, partition, offset)
+	if err != nil {
+		s.logger.Warn("Error fetching record timestamp for lag_time",
+			zap.String("topic", topic), zap.Int32("partition", partition), zap.Int64("offset", offset), zap.Error(err))
+		return time.Time{}, false
+	}
+
+	if s.lagTimeCache == nil {
+		s.lagTimeCache = map[lagTimeCacheKey]lagTimeCacheEntry{}
+	}
+	s.lagTimeCache[key] = lagTimeCacheEntry{timestamp: ts, cachedAt: now}
+	return ts, true
+}
+
+// cache returns s.metadataCache, falling back to a new cache with
+// MetadataRefreshInterval for scrapers constructed without one (e.g. test
+// fixtures that build consumerScraper{} literals directly). That fallback is
+// not kept, so nothing is reused across scrapes.
+func (s *consumerScraper) cache() *metadataCache {
+	if s.metadataCache == nil {
+		return newMetadataCache(s.config.MetadataRefreshInterval)
+	}
+	return s.metadataCache
+}
+
+func (s *consumerScraper) fetchRecordTimestamp(topic string, partition int32, offset int64) (time.Time, error) {
+	if s.fetchRecordTimestampFn != nil {
+		return s.fetchRecordTimestampFn(topic, partition, offset)
+	}
+	return fetchRecordTimestampFromBroker(s.client, topic, partition, offset)
+}
+
+// fetchRecordTimestampFromBroker issues a low-overhead fetch (MaxBytes large
+// enough for a single record batch header) against the partition leader and
+// returns the first timestamp of the record batch holding offset, which
+// approximates the timestamp of the record stored at offset; the per-record
+// timestamp delta within the batch is not applied.
+func fetchRecordTimestampFromBroker(client sarama.Client, topic string, partition int32, offset int64) (time.Time, error) {
+	broker, err := client.Leader(topic, partition)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to find leader for %s/%d: %w", topic, partition, err)
+	}
+
+	req := &sarama.FetchRequest{MinBytes: 1, MaxWaitTime: 250, Version: 4}
+	req.AddBlock(topic, partition, offset, 1024)
+
+	resp, err := broker.Fetch(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch %s/%d at offset %d: %w", topic, partition, offset, err)
+	}
+
+	block := resp.GetBlock(topic, partition)
+	if block == nil || len(block.RecordsSet) == 0 || block.RecordsSet[0].RecordBatch == nil {
+		return time.Time{}, fmt.Errorf("no record batch returned for %s/%d at offset %d", topic, partition, offset)
+	}
+	return block.RecordsSet[0].RecordBatch.FirstTimestamp, nil
+}
+
+func createConsumerScraper(_ context.Context, cfg Config, buildInfo component.BuildInfo, saramaConfig *sarama.Config, logger *zap.Logger, cache *metadataCache, tracerProvider trace.TracerProvider) (scraperhelper.Scraper, error) {
+	groupFilter, err := regexp.Compile(cfg.GroupMatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile group_match: %w", err)
+	}
+	topicFilter, err := regexp.Compile(cfg.TopicMatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile topic_match: %w", err)
+	}
+
+	s := consumerScraper{
+		logger:         logger,
+		topicFilter:    topicFilter,
+		groupFilter:    groupFilter,
+		saramaConfig:   saramaConfig,
+		config:         cfg,
+		mb:             metadata.NewMetricsBuilder(cfg.Metrics, buildInfo),
+		metadataCache:  cache,
+		tracerProvider: tracerProvider,
+	}
+	return scraperhelper.NewScraper(
+		s.Name(),
+		s.scrape,
+		scraperhelper.WithStart(s.start),
+		scraperhelper.WithShutdown(s.shutdown),
+	)
+}
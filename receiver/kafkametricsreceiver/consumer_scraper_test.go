@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 
@@ -63,7 +65,7 @@ func TestConsumerScraper_createConsumerScraper(t *testing.T) {
 	sc := sarama.NewConfig()
 	newSaramaClient = mockNewSaramaClient
 	newClusterAdmin = mockNewClusterAdmin
-	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop())
+	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, cs)
 }
@@ -73,7 +75,7 @@ func TestConsumerScraper_scrape_handles_client_error(t *testing.T) {
 		return nil, fmt.Errorf("new client failed")
 	}
 	sc := sarama.NewConfig()
-	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop())
+	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, cs)
 	_, err = cs.Scrape(context.Background())
@@ -85,7 +87,7 @@ func TestConsumerScraper_scrape_handles_nil_client(t *testing.T) {
 		return nil, fmt.Errorf("new client failed")
 	}
 	sc := sarama.NewConfig()
-	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop())
+	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, cs)
 	err = cs.Shutdown(context.Background())
@@ -103,7 +105,7 @@ func TestConsumerScraper_scrape_handles_clusterAdmin_error(t *testing.T) {
 		return nil, fmt.Errorf("new cluster admin failed")
 	}
 	sc := sarama.NewConfig()
-	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop())
+	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, cs)
 	_, err = cs.Scrape(context.Background())
@@ -114,7 +116,7 @@ func TestConsumerScraperStart(t *testing.T) {
 	newSaramaClient = mockNewSaramaClient
 	newClusterAdmin = mockNewClusterAdmin
 	sc := sarama.NewConfig()
-	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop())
+	cs, err := createConsumerScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, cs)
 	err = cs.Start(context.Background(), nil)
@@ -127,7 +129,7 @@ func TestConsumerScraper_createScraper_handles_invalid_topic_match(t *testing.T)
 	sc := sarama.NewConfig()
 	cs, err := createConsumerScraper(context.Background(), Config{
 		TopicMatch: "[",
-	}, component.NewDefaultBuildInfo(), sc, zap.NewNop())
+	}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, cs)
 }
@@ -138,7 +140,7 @@ func TestConsumerScraper_createScraper_handles_invalid_group_match(t *testing.T)
 	sc := sarama.NewConfig()
 	cs, err := createConsumerScraper(context.Background(), Config{
 		GroupMatch: "[",
-	}, component.NewDefaultBuildInfo(), sc, zap.NewNop())
+	}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, cs)
 }
@@ -226,6 +228,183 @@ func TestConsumerScraper_scrape_handlesOffsetPartialError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestConsumerScraper_scrape_recordsGroupStateMembersAndAssignedPartitions(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	config := createDefaultConfig().(*Config)
+	cs := consumerScraper{
+		client:       newMockClient(),
+		logger:       zap.NewNop(),
+		clusterAdmin: newMockClusterAdmin(),
+		topicFilter:  filter,
+		groupFilter:  filter,
+		mb:           metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := cs.scrape(context.Background())
+	assert.NoError(t, err)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	var sawState, sawMembers, sawAssigned bool
+	for i := 0; i < ms.Len(); i++ {
+		switch ms.At(i).Name() {
+		case "kafka.consumer_group.state":
+			sawState = true
+		case "kafka.consumer_group.members":
+			sawMembers = true
+		case "kafka.consumer_group.member_assigned_partitions":
+			sawAssigned = true
+		}
+	}
+	assert.True(t, sawState)
+	assert.True(t, sawMembers)
+	assert.True(t, sawAssigned)
+}
+
+func TestConsumerScraper_scrape_handlesMalformedMemberAssignment(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	config := createDefaultConfig().(*Config)
+	clusterAdmin := newMockClusterAdmin()
+	clusterAdmin.consumerGroupDescriptions = append(clusterAdmin.consumerGroupDescriptions, &sarama.GroupDescription{
+		GroupId: "group2",
+		State:   "Dead",
+		Members: map[string]*sarama.GroupMemberDescription{
+			"member2": {
+				ClientId:         "client2",
+				ClientHost:       "host2",
+				MemberAssignment: []byte{0xff, 0xff, 0xff},
+			},
+		},
+	})
+	cs := consumerScraper{
+		client:       newMockClient(),
+		logger:       zap.NewNop(),
+		clusterAdmin: clusterAdmin,
+		topicFilter:  filter,
+		groupFilter:  filter,
+		mb:           metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := cs.scrape(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, md)
+}
+
+func TestConsumerScraper_scrape_handlesDescribeConsumerGroupsPartialError(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	config := createDefaultConfig().(*Config)
+	clusterAdmin := newMockClusterAdmin()
+	clusterAdmin.consumerGroupDescriptions = append(clusterAdmin.consumerGroupDescriptions, &sarama.GroupDescription{
+		GroupId: "group2",
+		Err:     sarama.ErrUnknownTopicOrPartition,
+	})
+	cs := consumerScraper{
+		client:       newMockClient(),
+		logger:       zap.NewNop(),
+		clusterAdmin: clusterAdmin,
+		topicFilter:  filter,
+		groupFilter:  filter,
+		mb:           metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := cs.scrape(context.Background())
+	assert.Error(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() != "kafka.consumer_group.state" {
+			continue
+		}
+		dps := ms.At(i).Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			group, _ := dps.At(j).Attributes().Get("group")
+			assert.NotEqual(t, "group2", group.Str())
+		}
+	}
+}
+
+func TestConsumerScraper_scrape_lagTimeCachesAcrossScrapes(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	defaultConfig := createDefaultConfig().(*Config)
+	client := newMockClient()
+	client.offset = 10
+	var probes int
+	cs := consumerScraper{
+		client:       client,
+		logger:       zap.NewNop(),
+		clusterAdmin: newMockClusterAdmin(),
+		topicFilter:  filter,
+		groupFilter:  filter,
+		config: Config{
+			MaxLagTimeProbesPerScrape: 10,
+			LagTimeCacheTTL:           time.Minute,
+		},
+		mb: metadata.NewMetricsBuilder(defaultConfig.Metrics, component.NewDefaultBuildInfo()),
+		fetchRecordTimestampFn: func(string, int32, int64) (time.Time, error) {
+			probes++
+			return time.Now(), nil
+		},
+	}
+
+	_, err := cs.scrape(context.Background())
+	assert.NoError(t, err)
+	firstScrapeProbes := probes
+	assert.Greater(t, firstScrapeProbes, 0)
+
+	_, err = cs.scrape(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, firstScrapeProbes, probes, "second scrape should reuse cached lag_time lookups and issue no new probes")
+}
+
+func TestConsumerScraper_scrape_lagTimeRespectsProbeLimit(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	defaultConfig := createDefaultConfig().(*Config)
+	client := newMockClient()
+	client.offset = 10
+	var probes int
+	cs := consumerScraper{
+		client:       client,
+		logger:       zap.NewNop(),
+		clusterAdmin: newMockClusterAdmin(),
+		topicFilter:  filter,
+		groupFilter:  filter,
+		config: Config{
+			MaxLagTimeProbesPerScrape: 1,
+			LagTimeCacheTTL:           time.Minute,
+		},
+		mb: metadata.NewMetricsBuilder(defaultConfig.Metrics, component.NewDefaultBuildInfo()),
+		fetchRecordTimestampFn: func(string, int32, int64) (time.Time, error) {
+			probes++
+			return time.Now(), nil
+		},
+	}
+
+	_, err := cs.scrape(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, probes, "scrape should stop probing once max_lag_time_probes_per_scrape is reached")
+}
+
+func TestConsumerScraper_scrape_lagTimeDisabledByZeroValueConfig(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	defaultConfig := createDefaultConfig().(*Config)
+	client := newMockClient()
+	client.offset = 10
+	called := false
+	cs := consumerScraper{
+		client:       client,
+		logger:       zap.NewNop(),
+		clusterAdmin: newMockClusterAdmin(),
+		topicFilter:  filter,
+		groupFilter:  filter,
+		mb:           metadata.NewMetricsBuilder(defaultConfig.Metrics, component.NewDefaultBuildInfo()),
+		fetchRecordTimestampFn: func(string, int32, int64) (time.Time, error) {
+			called = true
+			return time.Now(), nil
+		},
+	}
+
+	_, err := cs.scrape(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, called, "a zero-value Config must leave lag_time probing disabled")
+}
+
 func TestConsumerScraper_scrape_handlesPartitionPartialError(t *testing.T) {
 	filter := regexp.MustCompile(defaultGroupMatch)
 	config := createDefaultConfig().(*Config)
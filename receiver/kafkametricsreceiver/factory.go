@@ -0,0 +1,126 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+const (
+	typeStr = "kafkametrics"
+
+	defaultTopicMatch = "^[^_].*$"
+	defaultGroupMatch = ".*"
+
+	defaultMaxLagTimeProbesPerScrape = 1000
+	defaultLagTimeCacheTTL           = 10 * time.Minute
+
+	defaultMetadataRefreshInterval = 5 * time.Minute
+)
+
+// NewFactory creates kafkametricsreceiver factory
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ScraperControllerSettings: scraperhelper.NewDefaultScraperControllerSettings(typeStr),
+		ProtocolVersion:           "2.0.0",
+		TopicMatch:                defaultTopicMatch,
+		GroupMatch:                defaultGroupMatch,
+		Scrapers:                  []string{"brokers", "topics", "consumers"},
+		Metrics:                   metadata.DefaultMetricsSettings(),
+		MaxLagTimeProbesPerScrape: defaultMaxLagTimeProbesPerScrape,
+		LagTimeCacheTTL:           defaultLagTimeCacheTTL,
+		MetadataRefreshInterval:   defaultMetadataRefreshInterval,
+		Client:                    ClientTypeSarama,
+	}
+}
+
+var (
+	newSaramaClient = sarama.NewClient
+	newClusterAdmin = sarama.NewClusterAdmin
+)
+
+func createMetricsReceiver(
+	ctx context.Context,
+	params receiver.CreateSettings,
+	cfg component.Config,
+	consumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	c := cfg.(*Config)
+
+	sc := sarama.NewConfig()
+	sc.ClientID = c.ClientID
+	var err error
+	if sc.Version, err = sarama.ParseKafkaVersion(c.ProtocolVersion); err != nil {
+		return nil, err
+	}
+
+	scraperFactories := map[string]func(context.Context, Config, component.BuildInfo, *sarama.Config, *zap.Logger, *metadataCache, trace.TracerProvider) (scraperhelper.Scraper, error){
+		"brokers":   createBrokerScraper,
+		"topics":    createTopicsScraper,
+		"consumers": createConsumerScraper,
+	}
+	if c.Client == ClientTypeREST {
+		scraperFactories = map[string]func(context.Context, Config, component.BuildInfo, *sarama.Config, *zap.Logger, *metadataCache, trace.TracerProvider) (scraperhelper.Scraper, error){
+			"brokers":   createRESTBrokerScraper,
+			"topics":    createRESTTopicsScraper,
+			"consumers": createRESTConsumerScraper,
+		}
+	}
+	scrapers := scraperFactories
+
+	// cache is shared by every sarama-backed scraper this receiver instance
+	// creates, so e.g. the topics and consumers scrapers reuse the same
+	// partition/replica metadata instead of each keeping their own copy.
+	cache := newMetadataCache(c.MetadataRefreshInterval)
+
+	var scraperControllerOptions []scraperhelper.ScraperControllerOption
+	for _, scraperName := range c.Scrapers {
+		create, ok := scrapers[scraperName]
+		if !ok {
+			return nil, fmt.Errorf("no scraper found for key: %s", scraperName)
+		}
+		scraper, err := create(ctx, *c, params.BuildInfo, sc, params.Logger, cache, params.TracerProvider)
+		if err != nil {
+			return nil, err
+		}
+		scraperControllerOptions = append(scraperControllerOptions, scraperhelper.AddScraper(scraper))
+	}
+
+	return scraperhelper.NewScraperControllerReceiver(
+		&c.ScraperControllerSettings,
+		params,
+		consumer,
+		scraperControllerOptions...,
+	)
+}
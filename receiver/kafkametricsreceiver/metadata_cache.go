@@ -0,0 +1,128 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// metadataCache memoizes the slow-changing per-topic metadata (partitions and
+// their replica assignments) that topicScraper and consumerScraper would
+// otherwise re-fetch from the cluster on every scrape. brokerScraper reads no
+// per-topic metadata and does not use it.
+// Fast-changing data (offsets, consumer group offsets) is never cached here
+// and is always read live by the scrapers.
+//
+// A metadataCache created by newMetadataCache with a zero refreshInterval
+// behaves as if caching were disabled: every call misses and re-fetches.
+type metadataCache struct {
+	mu              sync.Mutex
+	refreshInterval time.Duration
+	entries         map[string]*metadataCacheEntry
+}
+
+type metadataCacheEntry struct {
+	partitions     []int32
+	replicas       map[int32][]int32
+	inSyncReplicas map[int32][]int32
+	cachedAt       time.Time
+}
+
+// newMetadataCache returns a metadataCache whose entries are reused for up to
+// refreshInterval before being refreshed from the cluster.
+func newMetadataCache(refreshInterval time.Duration) *metadataCache {
+	return &metadataCache{refreshInterval: refreshInterval, entries: map[string]*metadataCacheEntry{}}
+}
+
+// partitions returns the partitions of topic, refreshing from client if the
+// cached entry is missing or older than refreshInterval.
+func (c *metadataCache) partitions(client sarama.Client, topic string, now time.Time) ([]int32, error) {
+	entry, err := c.entryFor(client, topic, now)
+	if err != nil {
+		return nil, err
+	}
+	return entry.partitions, nil
+}
+
+// replicas returns the replica set of topic/partition, refreshing from client
+// if the cached entry is missing or older than refreshInterval.
+func (c *metadataCache) replicas(client sarama.Client, topic string, partition int32, now time.Time) ([]int32, error) {
+	entry, err := c.entryFor(client, topic, now)
+	if err != nil {
+		return nil, err
+	}
+	return entry.replicas[partition], nil
+}
+
+// inSyncReplicas returns the in-sync replica set of topic/partition,
+// refreshing from client if the cached entry is missing or older than
+// refreshInterval.
+func (c *metadataCache) inSyncReplicas(client sarama.Client, topic string, partition int32, now time.Time) ([]int32, error) {
+	entry, err := c.entryFor(client, topic, now)
+	if err != nil {
+		return nil, err
+	}
+	return entry.inSyncReplicas[partition], nil
+}
+
+// invalidate discards any cached entry for topic, forcing the next lookup to
+// refresh from the cluster. Callers should invalidate a topic whenever a live
+// call against it returns sarama.ErrLeaderNotAvailable or
+// sarama.ErrUnknownTopicOrPartition, since either indicates the cached
+// partition/replica assignment may be stale.
+func (c *metadataCache) invalidate(topic string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, topic)
+}
+
+func (c *metadataCache) entryFor(client sarama.Client, topic string, now time.Time) (*metadataCacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[topic]; ok && now.Sub(entry.cachedAt) < c.refreshInterval {
+		return entry, nil
+	}
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+	replicas := map[int32][]int32{}
+	inSyncReplicas := map[int32][]int32{}
+	for _, partition := range partitions {
+		if r, rErr := client.Replicas(topic, partition); rErr == nil {
+			replicas[partition] = r
+		}
+		if isr, isrErr := client.InSyncReplicas(topic, partition); isrErr == nil {
+			inSyncReplicas[partition] = isr
+		}
+	}
+
+	entry := &metadataCacheEntry{partitions: partitions, replicas: replicas, inSyncReplicas: inSyncReplicas, cachedAt: now}
+	c.entries[topic] = entry
+	return entry, nil
+}
+
+// isStaleMetadataErr reports whether err indicates the cluster's view of a
+// topic/partition's leadership or existence has changed since it was last
+// cached.
+func isStaleMetadataErr(err error) bool {
+	return errors.Is(err, sarama.ErrLeaderNotAvailable) || errors.Is(err, sarama.ErrUnknownTopicOrPartition)
+}
@@ -0,0 +1,107 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingClient wraps a mockClient and counts calls to Partitions, so tests
+// can assert how many times metadataCache actually reached the cluster.
+type countingClient struct {
+	*mockClient
+	partitionsCalls int
+}
+
+func (c *countingClient) Partitions(topic string) ([]int32, error) {
+	c.partitionsCalls++
+	return c.mockClient.Partitions(topic)
+}
+
+func TestMetadataCache_reusesEntryWithinRefreshInterval(t *testing.T) {
+	client := &countingClient{mockClient: newMockClient()}
+	cache := newMetadataCache(10 * time.Minute)
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		partitions, err := cache.partitions(client, "my-topic", now)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{0, 1, 2}, partitions)
+	}
+
+	assert.Equal(t, 1, client.partitionsCalls, "5 scrapes within the refresh interval should only hit the cluster once")
+}
+
+func TestMetadataCache_refreshesAfterInterval(t *testing.T) {
+	client := &countingClient{mockClient: newMockClient()}
+	cache := newMetadataCache(10 * time.Minute)
+	now := time.Now()
+
+	_, err := cache.partitions(client, "my-topic", now)
+	require.NoError(t, err)
+	_, err = cache.partitions(client, "my-topic", now.Add(5*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.partitionsCalls)
+
+	_, err = cache.partitions(client, "my-topic", now.Add(11*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.partitionsCalls, "a lookup past the refresh interval should re-fetch from the cluster")
+}
+
+func TestMetadataCache_invalidateForcesRefresh(t *testing.T) {
+	client := &countingClient{mockClient: newMockClient()}
+	cache := newMetadataCache(10 * time.Minute)
+	now := time.Now()
+
+	_, err := cache.partitions(client, "my-topic", now)
+	require.NoError(t, err)
+	cache.invalidate("my-topic")
+
+	_, err = cache.partitions(client, "my-topic", now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.partitionsCalls, "invalidate should force the next lookup to re-fetch even within the interval")
+}
+
+func TestMetadataCache_zeroRefreshIntervalAlwaysMisses(t *testing.T) {
+	client := &countingClient{mockClient: newMockClient()}
+	cache := newMetadataCache(0)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		_, err := cache.partitions(client, "my-topic", now)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 3, client.partitionsCalls, "a zero refresh interval disables caching")
+}
+
+func TestMetadataCache_replicasAndInSyncReplicasShareEntry(t *testing.T) {
+	client := &countingClient{mockClient: newMockClient()}
+	cache := newMetadataCache(10 * time.Minute)
+	now := time.Now()
+
+	_, err := cache.partitions(client, "my-topic", now)
+	require.NoError(t, err)
+	_, err = cache.replicas(client, "my-topic", 0, now)
+	require.NoError(t, err)
+	_, err = cache.inSyncReplicas(client, "my-topic", 0, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, client.partitionsCalls, "replicas/inSyncReplicas should reuse the entry partitions() already populated")
+}
@@ -0,0 +1,281 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockClient is a minimal sarama.Client test double. Only the methods
+// exercised by the scrapers behave according to the struct fields below;
+// everything else is a thin passthrough so the type satisfies the interface.
+type mockClient struct {
+	mock.Mock
+	closed         bool
+	close          error
+	offset         int64
+	partitions     []int32
+	topics         []string
+	brokers        []*sarama.Broker
+	replicas       map[int32][]int32
+	inSyncReplicas map[int32][]int32
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{
+		offset:     5,
+		partitions: []int32{0, 1, 2},
+		topics:     []string{"topic1", "topic2"},
+		replicas: map[int32][]int32{
+			0: {1, 2},
+			1: {1, 2},
+			2: {1, 2},
+		},
+		inSyncReplicas: map[int32][]int32{
+			0: {1, 2},
+			1: {1, 2},
+			2: {1, 2},
+		},
+	}
+}
+
+func (c *mockClient) Partitions(string) ([]int32, error) {
+	if c.partitions == nil {
+		return nil, fmt.Errorf("failed to get partitions")
+	}
+	return c.partitions, nil
+}
+
+func (c *mockClient) WritablePartitions(string) ([]int32, error) {
+	return c.partitions, nil
+}
+
+func (c *mockClient) GetOffset(string, int32, int64) (int64, error) {
+	if c.offset < 0 {
+		return 0, fmt.Errorf("failed to get offset")
+	}
+	return c.offset, nil
+}
+
+func (c *mockClient) Close() error {
+	args := c.Called()
+	return args.Error(0)
+}
+
+func (c *mockClient) Closed() bool {
+	args := c.Called()
+	return args.Bool(0)
+}
+
+func (c *mockClient) Config() *sarama.Config                       { return sarama.NewConfig() }
+func (c *mockClient) Controller() (*sarama.Broker, error)          { return nil, nil }
+func (c *mockClient) RefreshController() (*sarama.Broker, error)   { return nil, nil }
+func (c *mockClient) Brokers() []*sarama.Broker                    { return c.brokers }
+func (c *mockClient) Broker(int32) (*sarama.Broker, error)         { return nil, nil }
+func (c *mockClient) Leader(string, int32) (*sarama.Broker, error) { return nil, nil }
+func (c *mockClient) LeaderAndEpoch(string, int32) (*sarama.Broker, int32, error) {
+	return nil, 0, nil
+}
+
+func (c *mockClient) Topics() ([]string, error) {
+	if c.topics == nil {
+		return nil, fmt.Errorf("failed to get topics")
+	}
+	return c.topics, nil
+}
+
+func (c *mockClient) Replicas(_ string, partition int32) ([]int32, error) {
+	if c.replicas == nil {
+		return nil, fmt.Errorf("failed to get replicas")
+	}
+	return c.replicas[partition], nil
+}
+
+func (c *mockClient) InSyncReplicas(_ string, partition int32) ([]int32, error) {
+	if c.inSyncReplicas == nil {
+		return nil, fmt.Errorf("failed to get in-sync replicas")
+	}
+	return c.inSyncReplicas[partition], nil
+}
+func (c *mockClient) OfflineReplicas(string, int32) ([]int32, error) { return nil, nil }
+func (c *mockClient) RefreshBrokers([]string) error                  { return nil }
+func (c *mockClient) RefreshMetadata(...string) error                { return nil }
+func (c *mockClient) Coordinator(string) (*sarama.Broker, error)     { return nil, nil }
+func (c *mockClient) RefreshCoordinator(string) error                { return nil }
+func (c *mockClient) CoordinatorForConsumerGroup(string) (*sarama.Broker, error) {
+	return nil, nil
+}
+func (c *mockClient) RefreshCoordinatorForConsumerGroup(string) error { return nil }
+func (c *mockClient) InitProducerID() (*sarama.InitProducerIDResponse, error) {
+	return nil, nil
+}
+func (c *mockClient) LeastLoadedBroker() *sarama.Broker { return nil }
+func (c *mockClient) TransactionCoordinator(string) (*sarama.Broker, error) {
+	return nil, nil
+}
+func (c *mockClient) RefreshTransactionCoordinator(string) error { return nil }
+
+func mockNewSaramaClient(_ []string, _ *sarama.Config) (sarama.Client, error) {
+	client := newMockClient()
+	client.closed = false
+	client.Mock.
+		On("Close").Return(nil).
+		On("Closed").Return(false)
+	return client, nil
+}
+
+// mockClusterAdmin is a minimal sarama.ClusterAdmin test double, driven by
+// the struct fields below for the group/topic metadata the scrapers need.
+type mockClusterAdmin struct {
+	mock.Mock
+	topics                    map[string]sarama.TopicDetail
+	consumerGroups            map[string]string
+	consumerGroupDescriptions []*sarama.GroupDescription
+	consumerGroupOffsets      *sarama.OffsetFetchResponse
+	closeErr                  error
+}
+
+func newMockClusterAdmin() *mockClusterAdmin {
+	return &mockClusterAdmin{
+		topics: map[string]sarama.TopicDetail{
+			"topic1": {},
+			"topic2": {},
+		},
+		consumerGroups: map[string]string{
+			"group1": "consumer",
+		},
+		consumerGroupDescriptions: []*sarama.GroupDescription{
+			{
+				GroupId: "group1",
+				State:   "Stable",
+				Members: map[string]*sarama.GroupMemberDescription{
+					"member1": {
+						ClientId:   "client1",
+						ClientHost: "host1",
+					},
+				},
+			},
+		},
+		consumerGroupOffsets: &sarama.OffsetFetchResponse{
+			Blocks: map[string]map[int32]*sarama.OffsetFetchResponseBlock{
+				"topic1": {
+					0: {Offset: 1, Err: sarama.ErrNoError},
+				},
+			},
+		},
+	}
+}
+
+func (a *mockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	if a.topics == nil {
+		return nil, fmt.Errorf("failed to list topics")
+	}
+	return a.topics, nil
+}
+
+func (a *mockClusterAdmin) ListConsumerGroups() (map[string]string, error) {
+	if a.consumerGroups == nil {
+		return nil, fmt.Errorf("failed to list consumer groups")
+	}
+	return a.consumerGroups, nil
+}
+
+func (a *mockClusterAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	if a.consumerGroupDescriptions == nil {
+		return nil, fmt.Errorf("failed to describe consumer groups")
+	}
+	return a.consumerGroupDescriptions, nil
+}
+
+func (a *mockClusterAdmin) ListConsumerGroupOffsets(string, map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	if a.consumerGroupOffsets == nil {
+		return nil, fmt.Errorf("failed to list consumer group offsets")
+	}
+	return a.consumerGroupOffsets, nil
+}
+
+func (a *mockClusterAdmin) Close() error {
+	return a.closeErr
+}
+
+func (a *mockClusterAdmin) CreateTopic(string, *sarama.TopicDetail, bool) error { return nil }
+func (a *mockClusterAdmin) ListTopicsWithConfig(map[string]sarama.TopicDetail) (map[string]sarama.TopicDetail, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) DeleteTopic(string) error                              { return nil }
+func (a *mockClusterAdmin) CreatePartitions(string, int32, [][]int32, bool) error { return nil }
+func (a *mockClusterAdmin) AlterPartitionReassignments(string, [][]int32) error   { return nil }
+func (a *mockClusterAdmin) ListPartitionReassignments(string, []int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) DeleteRecords(string, map[int32]int64) error { return nil }
+func (a *mockClusterAdmin) DescribeConfig(sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) AlterConfig(sarama.ConfigResourceType, string, map[string]*string, bool) error {
+	return nil
+}
+func (a *mockClusterAdmin) IncrementalAlterConfig(sarama.ConfigResourceType, string, map[string]sarama.IncrementalAlterConfigsEntry, bool) error {
+	return nil
+}
+func (a *mockClusterAdmin) CreateACL(sarama.Resource, sarama.Acl) error { return nil }
+func (a *mockClusterAdmin) CreateACLs([]*sarama.ResourceAcls) error     { return nil }
+func (a *mockClusterAdmin) ListAcls(sarama.AclFilter) ([]sarama.ResourceAcls, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) DeleteACL(sarama.AclFilter, bool) ([]sarama.MatchingAcl, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) DescribeCluster() ([]*sarama.Broker, int32, error) { return nil, 0, nil }
+func (a *mockClusterAdmin) DescribeTopics([]string) ([]*sarama.TopicMetadata, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) DeleteConsumerGroup(string) error { return nil }
+func (a *mockClusterAdmin) DeleteConsumerGroupOffset(string, string, int32) error {
+	return nil
+}
+func (a *mockClusterAdmin) DescribeLogDirs([]int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) DescribeUserScramCredentials([]string) ([]*sarama.DescribeUserScramCredentialsResult, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) DeleteUserScramCredentials([]sarama.AlterUserScramCredentialsDelete) ([]*sarama.AlterUserScramCredentialsResult, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) UpsertUserScramCredentials([]sarama.AlterUserScramCredentialsUpsert) ([]*sarama.AlterUserScramCredentialsResult, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) Controller() (*sarama.Broker, error)        { return nil, nil }
+func (a *mockClusterAdmin) Coordinator(string) (*sarama.Broker, error) { return nil, nil }
+func (a *mockClusterAdmin) AlterClientQuotas([]sarama.QuotaFilterComponent, sarama.ClientQuotasOp, bool) error {
+	return nil
+}
+func (a *mockClusterAdmin) DescribeClientQuotas([]sarama.QuotaFilterComponent) ([]sarama.DescribeClientQuotasEntry, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) ElectLeaders(sarama.ElectionType, map[string][]int32) (map[string]map[int32]*sarama.PartitionResult, error) {
+	return nil, nil
+}
+func (a *mockClusterAdmin) RemoveMemberFromConsumerGroup(string, []string) (*sarama.LeaveGroupResponse, error) {
+	return nil, nil
+}
+
+func mockNewClusterAdmin(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+	return newMockClusterAdmin(), nil
+}
@@ -0,0 +1,190 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// restClient talks to a Confluent-compatible Kafka REST API (the v3 admin API
+// exposed by Confluent Platform/Cloud, MSK Connect's REST proxy and Redpanda
+// Console) to provide the same broker/topic/consumer-group metadata the
+// sarama-backed scrapers get over the native protocol.
+type restClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	clusterID   string
+	username    string
+	password    string
+	bearerToken string
+}
+
+type restClusterList struct {
+	Data []struct {
+		ClusterID string `json:"cluster_id"`
+	} `json:"data"`
+}
+
+type restTopic struct {
+	TopicName          string `json:"topic_name"`
+	PartitionsCount    int    `json:"partitions_count"`
+	ReplicasAssignment []struct {
+		PartitionID int   `json:"partition_id"`
+		Replicas    []int `json:"replicas"`
+	} `json:"replicas_assignment,omitempty"`
+}
+
+type restTopicList struct {
+	Data []restTopic `json:"data"`
+}
+
+type restConsumerGroup struct {
+	ConsumerGroupID string `json:"consumer_group_id"`
+	State           string `json:"state"`
+}
+
+type restConsumerGroupList struct {
+	Data []restConsumerGroup `json:"data"`
+}
+
+type restConsumer struct {
+	ConsumerID string `json:"consumer_id"`
+	ClientID   string `json:"client_id"`
+	InstanceID string `json:"instance_id"`
+}
+
+type restConsumerList struct {
+	Data []restConsumer `json:"data"`
+}
+
+type restLag struct {
+	TopicName     string `json:"topic_name"`
+	PartitionID   int    `json:"partition_id"`
+	ConsumerID    string `json:"consumer_id"`
+	CurrentOffset int64  `json:"current_offset"`
+	LogEndOffset  int64  `json:"log_end_offset"`
+	Lag           int64  `json:"lag"`
+}
+
+type restLagList struct {
+	Data []restLag `json:"data"`
+}
+
+func (c *restClient) resolveClusterID(ctx context.Context) (string, error) {
+	if c.clusterID != "" {
+		return c.clusterID, nil
+	}
+	var clusters restClusterList
+	if err := c.get(ctx, "/v3/clusters", &clusters); err != nil {
+		return "", fmt.Errorf("failed to list clusters: %w", err)
+	}
+	if len(clusters.Data) == 0 {
+		return "", fmt.Errorf("no clusters returned by %s/v3/clusters", c.baseURL)
+	}
+	c.clusterID = clusters.Data[0].ClusterID
+	return c.clusterID, nil
+}
+
+func (c *restClient) listTopics(ctx context.Context) ([]restTopic, error) {
+	clusterID, err := c.resolveClusterID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var topics restTopicList
+	if err := c.get(ctx, fmt.Sprintf("/v3/clusters/%s/topics", clusterID), &topics); err != nil {
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+	return topics.Data, nil
+}
+
+func (c *restClient) listConsumerGroups(ctx context.Context) ([]restConsumerGroup, error) {
+	clusterID, err := c.resolveClusterID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var groups restConsumerGroupList
+	if err := c.get(ctx, fmt.Sprintf("/v3/clusters/%s/consumer-groups", clusterID), &groups); err != nil {
+		return nil, fmt.Errorf("failed to list consumer groups: %w", err)
+	}
+	return groups.Data, nil
+}
+
+func (c *restClient) listConsumers(ctx context.Context, group string) ([]restConsumer, error) {
+	clusterID, err := c.resolveClusterID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var consumers restConsumerList
+	if err := c.get(ctx, fmt.Sprintf("/v3/clusters/%s/consumer-groups/%s/consumers", clusterID, group), &consumers); err != nil {
+		return nil, fmt.Errorf("failed to list consumers for group %s: %w", group, err)
+	}
+	return consumers.Data, nil
+}
+
+func (c *restClient) listLags(ctx context.Context, group string) ([]restLag, error) {
+	clusterID, err := c.resolveClusterID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var lags restLagList
+	if err := c.get(ctx, fmt.Sprintf("/v3/clusters/%s/consumer-groups/%s/lags", clusterID, group), &lags); err != nil {
+		return nil, fmt.Errorf("failed to list lags for group %s: %w", group, err)
+	}
+	return lags.Data, nil
+}
+
+func (c *restClient) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	} else if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, path)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func newRESTClient(ctx context.Context, cfg RESTClientConfig, host component.Host, telemetry component.TelemetrySettings) (*restClient, error) {
+	httpClient, err := cfg.HTTPClientSettings.ToClient(host, telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build REST client: %w", err)
+	}
+	return &restClient{
+		httpClient:  httpClient,
+		baseURL:     cfg.Endpoint,
+		clusterID:   cfg.ClusterID,
+		username:    cfg.Username,
+		password:    cfg.Password,
+		bearerToken: cfg.BearerToken,
+	}, nil
+}
@@ -0,0 +1,177 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestRESTClient_resolveClusterID_cached(t *testing.T) {
+	client := &restClient{httpClient: http.DefaultClient, clusterID: "cluster-1"}
+	id, err := client.resolveClusterID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "cluster-1", id)
+}
+
+func TestRESTClient_resolveClusterID_fetchesAndCaches(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/v3/clusters", r.URL.Path)
+		w.Write([]byte(`{"data":[{"cluster_id":"cluster-xyz"}]}`))
+	}))
+	defer server.Close()
+
+	client := &restClient{httpClient: http.DefaultClient, baseURL: server.URL}
+	id, err := client.resolveClusterID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "cluster-xyz", id)
+
+	id, err = client.resolveClusterID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "cluster-xyz", id)
+	assert.Equal(t, 1, calls, "resolveClusterID should only hit the API once and cache the result")
+}
+
+func TestRESTClient_resolveClusterID_handlesEmptyClusterList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := &restClient{httpClient: http.DefaultClient, baseURL: server.URL}
+	_, err := client.resolveClusterID(context.Background())
+	assert.Error(t, err)
+}
+
+func TestRESTClient_get_usesBearerTokenOverBasicAuth(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &restClient{
+		httpClient:  http.DefaultClient,
+		baseURL:     server.URL,
+		username:    "user",
+		password:    "pass",
+		bearerToken: "token123",
+	}
+	var out map[string]any
+	err := client.get(context.Background(), "/v3/clusters", &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token123", gotAuth)
+}
+
+func TestRESTClient_get_fallsBackToBasicAuth(t *testing.T) {
+	var gotUser, gotPass string
+	var hasAuth bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, gotPass, hasAuth = r.BasicAuth()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &restClient{
+		httpClient: http.DefaultClient,
+		baseURL:    server.URL,
+		username:   "user",
+		password:   "pass",
+	}
+	var out map[string]any
+	err := client.get(context.Background(), "/v3/clusters", &out)
+	require.NoError(t, err)
+	assert.True(t, hasAuth)
+	assert.Equal(t, "user", gotUser)
+	assert.Equal(t, "pass", gotPass)
+}
+
+func TestRESTClient_get_noAuthConfigured(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &restClient{httpClient: http.DefaultClient, baseURL: server.URL}
+	var out map[string]any
+	err := client.get(context.Background(), "/v3/clusters", &out)
+	require.NoError(t, err)
+	assert.Empty(t, gotAuth)
+}
+
+func TestRESTClient_get_handlesNon200Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &restClient{httpClient: http.DefaultClient, baseURL: server.URL}
+	var out map[string]any
+	err := client.get(context.Background(), "/v3/clusters", &out)
+	assert.Error(t, err)
+}
+
+func TestRESTClient_listTopics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/clusters/cluster-1/topics":
+			w.Write([]byte(`{"data":[{"topic_name":"topic1","partitions_count":3}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &restClient{httpClient: http.DefaultClient, baseURL: server.URL, clusterID: "cluster-1"}
+	topics, err := client.listTopics(context.Background())
+	require.NoError(t, err)
+	require.Len(t, topics, 1)
+	assert.Equal(t, "topic1", topics[0].TopicName)
+	assert.Equal(t, 3, topics[0].PartitionsCount)
+}
+
+func TestRESTClient_listTopics_propagatesResolveClusterIDError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &restClient{httpClient: http.DefaultClient, baseURL: server.URL}
+	_, err := client.listTopics(context.Background())
+	assert.Error(t, err)
+}
+
+func TestNewRESTClient(t *testing.T) {
+	cfg := RESTClientConfig{ClusterID: "cluster-1", Username: "user", Password: "pass"}
+	cfg.Endpoint = "http://localhost:8082"
+	client, err := newRESTClient(context.Background(), cfg, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NotNil(t, client)
+	assert.Equal(t, "http://localhost:8082", client.baseURL)
+	assert.Equal(t, "cluster-1", client.clusterID)
+	assert.Equal(t, "user", client.username)
+	assert.Equal(t, "pass", client.password)
+}
@@ -0,0 +1,200 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+// restBrokerScraper, restTopicScraper and restConsumerScraper are the "rest"
+// client counterparts of brokerScraper, topicScraper and consumerScraper:
+// they source the underlying metadata from a Confluent-compatible REST API
+// instead of sarama and emit a subset of the same metrics. restBrokerScraper
+// reports kafka.brokers as 1 for the configured cluster, not its broker count.
+// restTopicScraper emits kafka.topic.partitions and kafka.partition.replicas
+// only. restConsumerScraper emits kafka.consumer_group.state, .members,
+// .offset and .lag only; offset_sum, lag_sum, lag_time and
+// member_assigned_partitions are not emitted.
+
+type restBrokerScraper struct {
+	client    *restClient
+	config    Config
+	mb        *metadata.MetricsBuilder
+	telemetry component.TelemetrySettings
+}
+
+func (s *restBrokerScraper) Name() string { return brokersScraperName }
+
+func (s *restBrokerScraper) start(ctx context.Context, host component.Host) error {
+	client, err := newRESTClient(ctx, s.config.REST, host, s.telemetry)
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+func (s *restBrokerScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if _, err := s.client.resolveClusterID(ctx); err != nil {
+		return pmetric.NewMetrics(), err
+	}
+	// The v3 API exposes a single cluster per base URL; report it as one broker
+	// set entry representing the cluster the receiver is configured against.
+	s.mb.RecordKafkaBrokersDataPoint(pcommon.NewTimestampFromTime(time.Now()), 1)
+	return s.mb.Emit(), nil
+}
+
+func createRESTBrokerScraper(ctx context.Context, cfg Config, buildInfo component.BuildInfo, _ *sarama.Config, logger *zap.Logger, _ *metadataCache, tracerProvider trace.TracerProvider) (scraperhelper.Scraper, error) {
+	s := restBrokerScraper{
+		config:    cfg,
+		mb:        metadata.NewMetricsBuilder(cfg.Metrics, buildInfo),
+		telemetry: component.TelemetrySettings{Logger: logger, TracerProvider: tracerProvider},
+	}
+	return scraperhelper.NewScraper(s.Name(), s.scrape, scraperhelper.WithStart(s.start))
+}
+
+type restTopicScraper struct {
+	client      *restClient
+	config      Config
+	topicFilter *regexp.Regexp
+	mb          *metadata.MetricsBuilder
+	telemetry   component.TelemetrySettings
+}
+
+func (s *restTopicScraper) Name() string { return topicsScraperName }
+
+func (s *restTopicScraper) start(ctx context.Context, host component.Host) error {
+	client, err := newRESTClient(ctx, s.config.REST, host, s.telemetry)
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+func (s *restTopicScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	topics, err := s.client.listTopics(ctx)
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, topic := range topics {
+		if !s.topicFilter.MatchString(topic.TopicName) {
+			continue
+		}
+		s.mb.RecordKafkaTopicPartitionsDataPoint(now, int64(topic.PartitionsCount), topic.TopicName)
+		for _, partition := range topic.ReplicasAssignment {
+			s.mb.RecordKafkaPartitionReplicasDataPoint(now, int64(len(partition.Replicas)), topic.TopicName, int64(partition.PartitionID))
+		}
+	}
+	return s.mb.Emit(), nil
+}
+
+func createRESTTopicsScraper(ctx context.Context, cfg Config, buildInfo component.BuildInfo, _ *sarama.Config, logger *zap.Logger, _ *metadataCache, tracerProvider trace.TracerProvider) (scraperhelper.Scraper, error) {
+	topicFilter, err := regexp.Compile(cfg.TopicMatch)
+	if err != nil {
+		return nil, err
+	}
+	s := restTopicScraper{
+		config:      cfg,
+		topicFilter: topicFilter,
+		mb:          metadata.NewMetricsBuilder(cfg.Metrics, buildInfo),
+		telemetry:   component.TelemetrySettings{Logger: logger, TracerProvider: tracerProvider},
+	}
+	return scraperhelper.NewScraper(s.Name(), s.scrape, scraperhelper.WithStart(s.start))
+}
+
+type restConsumerScraper struct {
+	client      *restClient
+	config      Config
+	groupFilter *regexp.Regexp
+	mb          *metadata.MetricsBuilder
+	telemetry   component.TelemetrySettings
+}
+
+func (s *restConsumerScraper) Name() string { return consumersScraperName }
+
+func (s *restConsumerScraper) start(ctx context.Context, host component.Host) error {
+	client, err := newRESTClient(ctx, s.config.REST, host, s.telemetry)
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+func (s *restConsumerScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	groups, err := s.client.listConsumerGroups(ctx)
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	var scrapeError error
+
+	for _, group := range groups {
+		if !s.groupFilter.MatchString(group.ConsumerGroupID) {
+			continue
+		}
+		s.mb.RecordKafkaConsumerGroupStateDataPoint(now, 1, group.ConsumerGroupID, group.State)
+
+		consumers, consumersErr := s.client.listConsumers(ctx, group.ConsumerGroupID)
+		if consumersErr != nil {
+			scrapeError = multierr.Append(scrapeError, consumersErr)
+		} else {
+			s.mb.RecordKafkaConsumerGroupMembersDataPoint(now, int64(len(consumers)), group.ConsumerGroupID)
+		}
+
+		lags, lagsErr := s.client.listLags(ctx, group.ConsumerGroupID)
+		if lagsErr != nil {
+			scrapeError = multierr.Append(scrapeError, lagsErr)
+			continue
+		}
+		for _, lag := range lags {
+			s.mb.RecordKafkaConsumerGroupOffsetDataPoint(now, lag.CurrentOffset, group.ConsumerGroupID, lag.TopicName, int64(lag.PartitionID))
+			s.mb.RecordKafkaConsumerGroupLagDataPoint(now, lag.Lag, group.ConsumerGroupID, lag.TopicName, int64(lag.PartitionID))
+		}
+	}
+
+	return s.mb.Emit(), scrapeError
+}
+
+func createRESTConsumerScraper(ctx context.Context, cfg Config, buildInfo component.BuildInfo, _ *sarama.Config, logger *zap.Logger, _ *metadataCache, tracerProvider trace.TracerProvider) (scraperhelper.Scraper, error) {
+	groupFilter, err := regexp.Compile(cfg.GroupMatch)
+	if err != nil {
+		return nil, err
+	}
+	s := restConsumerScraper{
+		config:      cfg,
+		groupFilter: groupFilter,
+		mb:          metadata.NewMetricsBuilder(cfg.Metrics, buildInfo),
+		telemetry:   component.TelemetrySettings{Logger: logger, TracerProvider: tracerProvider},
+	}
+	return scraperhelper.NewScraper(s.Name(), s.scrape, scraperhelper.WithStart(s.start))
+}
@@ -0,0 +1,217 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+func TestRESTBrokerScraper_start(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	s := restBrokerScraper{config: *config}
+	err := s.start(context.Background(), componenttest.NewNopHost())
+	assert.NoError(t, err)
+	assert.NotNil(t, s.client)
+}
+
+func TestRESTBrokerScraper_scrape(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[{"cluster_id":"cluster-1"}]}`))
+	}))
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	s := restBrokerScraper{
+		client: &restClient{httpClient: http.DefaultClient, baseURL: server.URL},
+		config: *config,
+		mb:     metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+}
+
+func TestRESTBrokerScraper_scrape_handlesResolveClusterIDError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	s := restBrokerScraper{
+		client: &restClient{httpClient: http.DefaultClient, baseURL: server.URL},
+		config: *config,
+		mb:     metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	_, err := s.scrape(context.Background())
+	assert.Error(t, err)
+}
+
+func TestRESTTopicScraper_start(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	s := restTopicScraper{config: *config}
+	err := s.start(context.Background(), componenttest.NewNopHost())
+	assert.NoError(t, err)
+	assert.NotNil(t, s.client)
+}
+
+func TestRESTTopicScraper_scrape(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/clusters/cluster-1/topics":
+			w.Write([]byte(`{"data":[{"topic_name":"topic1","partitions_count":2,"replicas_assignment":[{"partition_id":0,"replicas":[1,2]}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	topicFilter := regexp.MustCompile(config.TopicMatch)
+	s := restTopicScraper{
+		client:      &restClient{httpClient: http.DefaultClient, baseURL: server.URL, clusterID: "cluster-1"},
+		config:      *config,
+		topicFilter: topicFilter,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+}
+
+func TestRESTTopicScraper_scrape_filtersNonMatchingTopics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[{"topic_name":"_internal","partitions_count":1}]}`))
+	}))
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	topicFilter := regexp.MustCompile(config.TopicMatch)
+	s := restTopicScraper{
+		client:      &restClient{httpClient: http.DefaultClient, baseURL: server.URL, clusterID: "cluster-1"},
+		config:      *config,
+		topicFilter: topicFilter,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+}
+
+func TestRESTTopicScraper_scrape_handlesListTopicsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	topicFilter := regexp.MustCompile(config.TopicMatch)
+	s := restTopicScraper{
+		client:      &restClient{httpClient: http.DefaultClient, baseURL: server.URL, clusterID: "cluster-1"},
+		config:      *config,
+		topicFilter: topicFilter,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	_, err := s.scrape(context.Background())
+	assert.Error(t, err)
+}
+
+func TestRESTConsumerScraper_start(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	s := restConsumerScraper{config: *config}
+	err := s.start(context.Background(), componenttest.NewNopHost())
+	assert.NoError(t, err)
+	assert.NotNil(t, s.client)
+}
+
+func TestRESTConsumerScraper_scrape(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/clusters/cluster-1/consumer-groups":
+			w.Write([]byte(`{"data":[{"consumer_group_id":"group1","state":"Stable"}]}`))
+		case "/v3/clusters/cluster-1/consumer-groups/group1/consumers":
+			w.Write([]byte(`{"data":[{"consumer_id":"consumer1"}]}`))
+		case "/v3/clusters/cluster-1/consumer-groups/group1/lags":
+			w.Write([]byte(`{"data":[{"topic_name":"topic1","partition_id":0,"current_offset":10,"log_end_offset":15,"lag":5}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	groupFilter := regexp.MustCompile(config.GroupMatch)
+	s := restConsumerScraper{
+		client:      &restClient{httpClient: http.DefaultClient, baseURL: server.URL, clusterID: "cluster-1"},
+		config:      *config,
+		groupFilter: groupFilter,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+}
+
+func TestRESTConsumerScraper_scrape_handlesListConsumerGroupsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	groupFilter := regexp.MustCompile(config.GroupMatch)
+	s := restConsumerScraper{
+		client:      &restClient{httpClient: http.DefaultClient, baseURL: server.URL, clusterID: "cluster-1"},
+		config:      *config,
+		groupFilter: groupFilter,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	_, err := s.scrape(context.Background())
+	assert.Error(t, err)
+}
+
+func TestRESTConsumerScraper_scrape_handlesListConsumersAndLagsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/clusters/cluster-1/consumer-groups":
+			w.Write([]byte(`{"data":[{"consumer_group_id":"group1","state":"Stable"}]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	groupFilter := regexp.MustCompile(config.GroupMatch)
+	s := restConsumerScraper{
+		client:      &restClient{httpClient: http.DefaultClient, baseURL: server.URL, clusterID: "cluster-1"},
+		config:      *config,
+		groupFilter: groupFilter,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	_, err := s.scrape(context.Background())
+	assert.Error(t, err)
+}
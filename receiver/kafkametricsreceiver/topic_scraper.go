@@ -0,0 +1,179 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+const (
+	topicsScraperName = "topics"
+)
+
+type topicScraper struct {
+	client       sarama.Client
+	logger       *zap.Logger
+	topicFilter  *regexp.Regexp
+	saramaConfig *sarama.Config
+	config       Config
+	mb           *metadata.MetricsBuilder
+
+	// metadataCache memoizes partition/replica metadata across scrapes. Left
+	// nil by test fixtures that construct topicScraper{} literals directly,
+	// in which case each scrape uses a fresh cache that lasts only for that
+	// scrape.
+	metadataCache *metadataCache
+
+	// tracerProvider, when non-nil, causes start to wrap the sarama client in
+	// a tracedClient so its calls are recorded as spans under this scraper's
+	// per-scrape kafkametricsreceiver.scrape span.
+	tracerProvider trace.TracerProvider
+}
+
+func (s *topicScraper) Name() string {
+	return topicsScraperName
+}
+
+func (s *topicScraper) shutdown(context.Context) error {
+	if s.client == nil || s.client.Closed() {
+		return nil
+	}
+	return s.client.Close()
+}
+
+func (s *topicScraper) start(context.Context, component.Host) error {
+	if s.client != nil {
+		return nil
+	}
+	client, err := newSaramaClient(s.config.Brokers, s.saramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	s.client = newTracedClient(client, s.tracerProvider, s.config.Brokers)
+	return nil
+}
+
+func (s *topicScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	ctx, span := startScrapeSpan(ctx, s.tracerProvider)
+	defer span.End()
+	if cs, ok := s.client.(contextSetter); ok {
+		cs.withContext(ctx)
+	}
+
+	topics, err := s.client.Topics()
+	if err != nil {
+		s.logger.Error("Error fetching topics", zap.Error(err))
+		return pmetric.NewMetrics(), err
+	}
+
+	nowTime := time.Now()
+	now := pcommon.NewTimestampFromTime(nowTime)
+	var scrapeError error
+	cache := s.cache()
+
+	for _, topic := range topics {
+		if !s.topicFilter.MatchString(topic) {
+			continue
+		}
+		partitions, partErr := cache.partitions(s.client, topic, nowTime)
+		if partErr != nil {
+			scrapeError = multierr.Append(scrapeError, partErr)
+			continue
+		}
+		s.mb.RecordKafkaTopicPartitionsDataPoint(now, int64(len(partitions)), topic)
+
+		for _, partition := range partitions {
+			current, curErr := s.client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if curErr != nil {
+				scrapeError = multierr.Append(scrapeError, curErr)
+				if isStaleMetadataErr(curErr) {
+					cache.invalidate(topic)
+				}
+			} else {
+				s.mb.RecordKafkaPartitionCurrentOffsetDataPoint(now, current, topic, int64(partition))
+			}
+
+			oldest, oldestErr := s.client.GetOffset(topic, partition, sarama.OffsetOldest)
+			if oldestErr != nil {
+				scrapeError = multierr.Append(scrapeError, oldestErr)
+			} else {
+				s.mb.RecordKafkaPartitionOldestOffsetDataPoint(now, oldest, topic, int64(partition))
+			}
+
+			replicas, replicasErr := cache.replicas(s.client, topic, partition, nowTime)
+			if replicasErr != nil {
+				scrapeError = multierr.Append(scrapeError, replicasErr)
+			} else {
+				s.mb.RecordKafkaPartitionReplicasDataPoint(now, int64(len(replicas)), topic, int64(partition))
+			}
+
+			inSyncReplicas, isrErr := cache.inSyncReplicas(s.client, topic, partition, nowTime)
+			if isrErr != nil {
+				scrapeError = multierr.Append(scrapeError, isrErr)
+			} else {
+				s.mb.RecordKafkaPartitionReplicasInSyncDataPoint(now, int64(len(inSyncReplicas)), topic, int64(partition))
+			}
+		}
+	}
+
+	return s.mb.Emit(), scrapeError
+}
+
+// cache returns s.metadataCache, falling back to a new cache with
+// MetadataRefreshInterval for scrapers constructed without one (e.g. test
+// fixtures that build topicScraper{} literals directly). That fallback is
+// not kept, so nothing is reused across scrapes.
+func (s *topicScraper) cache() *metadataCache {
+	if s.metadataCache == nil {
+		return newMetadataCache(s.config.MetadataRefreshInterval)
+	}
+	return s.metadataCache
+}
+
+func createTopicsScraper(_ context.Context, cfg Config, buildInfo component.BuildInfo, saramaConfig *sarama.Config, logger *zap.Logger, cache *metadataCache, tracerProvider trace.TracerProvider) (scraperhelper.Scraper, error) {
+	topicFilter, err := regexp.Compile(cfg.TopicMatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile topic_match: %w", err)
+	}
+	s := topicScraper{
+		logger:         logger,
+		topicFilter:    topicFilter,
+		saramaConfig:   saramaConfig,
+		config:         cfg,
+		mb:             metadata.NewMetricsBuilder(cfg.Metrics, buildInfo),
+		metadataCache:  cache,
+		tracerProvider: tracerProvider,
+	}
+	return scraperhelper.NewScraper(
+		s.Name(),
+		s.scrape,
+		scraperhelper.WithStart(s.start),
+		scraperhelper.WithShutdown(s.shutdown),
+	)
+}
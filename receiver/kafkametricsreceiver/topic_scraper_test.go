@@ -0,0 +1,151 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
+)
+
+func TestTopicShutdown(t *testing.T) {
+	client := newMockClient()
+	client.closed = false
+	client.close = nil
+	client.Mock.
+		On("Close").Return(nil).
+		On("Closed").Return(false)
+	scraper := topicScraper{
+		client: client,
+	}
+	_ = scraper.shutdown(context.Background())
+	client.AssertExpectations(t)
+}
+
+func TestTopicScraper_Name(t *testing.T) {
+	s := topicScraper{}
+	assert.Equal(t, s.Name(), topicsScraperName)
+}
+
+func TestTopicScraper_createTopicsScraper(t *testing.T) {
+	sc := sarama.NewConfig()
+	newSaramaClient = mockNewSaramaClient
+	ts, err := createTopicsScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, ts)
+}
+
+func TestTopicScraper_createScraper_handles_invalid_topic_match(t *testing.T) {
+	sc := sarama.NewConfig()
+	ts, err := createTopicsScraper(context.Background(), Config{
+		TopicMatch: "[",
+	}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, ts)
+}
+
+func TestTopicScraperStart(t *testing.T) {
+	newSaramaClient = mockNewSaramaClient
+	sc := sarama.NewConfig()
+	ts, err := createTopicsScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, ts)
+	err = ts.Start(context.Background(), nil)
+	assert.NoError(t, err)
+}
+
+func TestTopicScraperStart_handlesError(t *testing.T) {
+	newSaramaClient = func(addrs []string, conf *sarama.Config) (sarama.Client, error) {
+		return nil, fmt.Errorf("new client failed")
+	}
+	sc := sarama.NewConfig()
+	ts, err := createTopicsScraper(context.Background(), Config{}, component.NewDefaultBuildInfo(), sc, zap.NewNop(), nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, ts)
+	err = ts.Start(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestTopicScraper_scrape(t *testing.T) {
+	filter := regexp.MustCompile(defaultTopicMatch)
+	config := createDefaultConfig().(*Config)
+	ts := topicScraper{
+		client:      newMockClient(),
+		logger:      zap.NewNop(),
+		topicFilter: filter,
+		config:      *config,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := ts.scrape(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, md)
+}
+
+func TestTopicScraper_scrape_handlesTopicsError(t *testing.T) {
+	filter := regexp.MustCompile(defaultTopicMatch)
+	config := createDefaultConfig().(*Config)
+	client := newMockClient()
+	client.topics = nil
+	ts := topicScraper{
+		client:      client,
+		logger:      zap.NewNop(),
+		topicFilter: filter,
+		config:      *config,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	_, err := ts.scrape(context.Background())
+	assert.Error(t, err)
+}
+
+func TestTopicScraper_scrape_handlesPartitionsError(t *testing.T) {
+	filter := regexp.MustCompile(defaultTopicMatch)
+	config := createDefaultConfig().(*Config)
+	client := newMockClient()
+	client.partitions = nil
+	ts := topicScraper{
+		client:      client,
+		logger:      zap.NewNop(),
+		topicFilter: filter,
+		config:      *config,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	_, err := ts.scrape(context.Background())
+	assert.Error(t, err)
+}
+
+func TestTopicScraper_scrape_handlesOffsetError(t *testing.T) {
+	filter := regexp.MustCompile(defaultTopicMatch)
+	config := createDefaultConfig().(*Config)
+	client := newMockClient()
+	client.offset = -1
+	ts := topicScraper{
+		client:      client,
+		logger:      zap.NewNop(),
+		topicFilter: filter,
+		config:      *config,
+		mb:          metadata.NewMetricsBuilder(config.Metrics, component.NewDefaultBuildInfo()),
+	}
+	md, err := ts.scrape(context.Background())
+	assert.Error(t, err)
+	assert.NotNil(t, md)
+}
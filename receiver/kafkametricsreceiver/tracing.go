@@ -0,0 +1,164 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver"
+
+// scrapeSpanName is the parent span every scraper starts around its scrape
+// cycle; the sarama calls issued during that cycle are recorded as its
+// children by tracedClient/tracedClusterAdmin.
+const scrapeSpanName = "kafkametricsreceiver.scrape"
+
+// startScrapeSpan starts the parent span for a single scrape cycle and
+// returns a context carrying it, ready to be handed to a tracedClient or
+// tracedClusterAdmin via withContext.
+func startScrapeSpan(ctx context.Context, tracerProvider trace.TracerProvider) (context.Context, trace.Span) {
+	if tracerProvider == nil {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return tracerProvider.Tracer(tracerName).Start(ctx, scrapeSpanName,
+		trace.WithAttributes(attribute.String("messaging.system", "kafka")))
+}
+
+// contextSetter lets a scraper hand the context of its current scrape span
+// to a tracedClient/tracedClusterAdmin so the spans it creates for sarama
+// calls nest under that scrape's parent span.
+type contextSetter interface {
+	withContext(ctx context.Context)
+}
+
+// traceState is embedded by tracedClient/tracedClusterAdmin to hold the
+// context of the in-flight scrape, since sarama's Client/ClusterAdmin
+// methods take no context argument of their own.
+type traceState struct {
+	tracer  trace.Tracer
+	brokers string
+
+	mu  sync.Mutex
+	ctx context.Context
+}
+
+func newTraceState(tracerProvider trace.TracerProvider, brokers []string) traceState {
+	return traceState{tracer: tracerProvider.Tracer(tracerName), brokers: strings.Join(brokers, ",")}
+}
+
+func (t *traceState) withContext(ctx context.Context) {
+	t.mu.Lock()
+	t.ctx = ctx
+	t.mu.Unlock()
+}
+
+func (t *traceState) context() context.Context {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ctx == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
+
+func (t *traceState) startSpan(name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("messaging.system", "kafka"), attribute.String("net.peer.name", t.brokers))
+	return t.tracer.Start(t.context(), name, trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedClient wraps a sarama.Client so that the calls the scrapers issue
+// against it produce a child span of the scrape's parent span. Every method
+// not explicitly overridden below is served directly by the embedded Client.
+type tracedClient struct {
+	sarama.Client
+	traceState
+}
+
+// newTracedClient wraps client so its calls are recorded as spans, or
+// returns client unchanged if tracerProvider is nil.
+func newTracedClient(client sarama.Client, tracerProvider trace.TracerProvider, brokers []string) sarama.Client {
+	if tracerProvider == nil {
+		return client
+	}
+	return &tracedClient{Client: client, traceState: newTraceState(tracerProvider, brokers)}
+}
+
+func (c *tracedClient) GetOffset(topic string, partition int32, time int64) (int64, error) {
+	_, span := c.startSpan("GetOffset", attribute.String("messaging.destination", topic), attribute.Int64("messaging.kafka.partition", int64(partition)))
+	offset, err := c.Client.GetOffset(topic, partition, time)
+	endSpan(span, err)
+	return offset, err
+}
+
+// tracedClusterAdmin wraps a sarama.ClusterAdmin so that the calls the
+// scrapers issue against it produce a child span of the scrape's parent
+// span. Every method not explicitly overridden below is served directly by
+// the embedded ClusterAdmin.
+type tracedClusterAdmin struct {
+	sarama.ClusterAdmin
+	traceState
+}
+
+// newTracedClusterAdmin wraps admin so its calls are recorded as spans, or
+// returns admin unchanged if tracerProvider is nil.
+func newTracedClusterAdmin(admin sarama.ClusterAdmin, tracerProvider trace.TracerProvider, brokers []string) sarama.ClusterAdmin {
+	if tracerProvider == nil {
+		return admin
+	}
+	return &tracedClusterAdmin{ClusterAdmin: admin, traceState: newTraceState(tracerProvider, brokers)}
+}
+
+func (a *tracedClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	_, span := a.startSpan("ListTopics")
+	topics, err := a.ClusterAdmin.ListTopics()
+	endSpan(span, err)
+	return topics, err
+}
+
+func (a *tracedClusterAdmin) ListConsumerGroups() (map[string]string, error) {
+	_, span := a.startSpan("ListConsumerGroups")
+	groups, err := a.ClusterAdmin.ListConsumerGroups()
+	endSpan(span, err)
+	return groups, err
+}
+
+func (a *tracedClusterAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	_, span := a.startSpan("DescribeConsumerGroups", attribute.StringSlice("messaging.kafka.consumer_group", groups))
+	descriptions, err := a.ClusterAdmin.DescribeConsumerGroups(groups)
+	endSpan(span, err)
+	return descriptions, err
+}
+
+func (a *tracedClusterAdmin) ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	_, span := a.startSpan("ListConsumerGroupOffsets", attribute.String("messaging.kafka.consumer_group", group))
+	offsets, err := a.ClusterAdmin.ListConsumerGroupOffsets(group, topicPartitions)
+	endSpan(span, err)
+	return offsets, err
+}
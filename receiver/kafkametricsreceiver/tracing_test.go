@@ -0,0 +1,111 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTracerProvider() (trace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func TestTracedClient_GetOffset_recordsSpan(t *testing.T) {
+	tp, recorder := newTestTracerProvider()
+	client := newMockClient()
+	traced := newTracedClient(client, tp, []string{"broker1:9092"})
+
+	parentCtx, parentSpan := tp.Tracer(tracerName).Start(context.Background(), scrapeSpanName)
+	traced.(contextSetter).withContext(parentCtx)
+
+	_, err := traced.GetOffset("my-topic", 0, -1)
+	require.NoError(t, err)
+	parentSpan.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GetOffset", spans[0].Name())
+	assert.Equal(t, parentSpan.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func TestTracedClient_GetOffset_recordsError(t *testing.T) {
+	tp, recorder := newTestTracerProvider()
+	client := newMockClient()
+	client.offset = -1
+	traced := newTracedClient(client, tp, nil)
+
+	_, err := traced.GetOffset("my-topic", 0, -1)
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codesError(spans[0]), true)
+}
+
+func TestTracedClusterAdmin_recordsSpansForEachCall(t *testing.T) {
+	tp, recorder := newTestTracerProvider()
+	admin := newMockClusterAdmin()
+	traced := newTracedClusterAdmin(admin, tp, []string{"broker1:9092"})
+
+	_, err := traced.ListTopics()
+	require.NoError(t, err)
+	_, err = traced.ListConsumerGroups()
+	require.NoError(t, err)
+	_, err = traced.DescribeConsumerGroups([]string{"my-group"})
+	require.NoError(t, err)
+	_, err = traced.ListConsumerGroupOffsets("my-group", map[string][]int32{"my-topic": {0}})
+	require.NoError(t, err)
+
+	names := map[string]bool{}
+	for _, span := range recorder.Ended() {
+		names[span.Name()] = true
+	}
+	for _, want := range []string{"ListTopics", "ListConsumerGroups", "DescribeConsumerGroups", "ListConsumerGroupOffsets"} {
+		assert.True(t, names[want], "expected a span named %s", want)
+	}
+}
+
+func TestTracedClusterAdmin_recordsErrorFromMock(t *testing.T) {
+	tp, recorder := newTestTracerProvider()
+	admin := newMockClusterAdmin()
+	admin.consumerGroups = nil
+	traced := newTracedClusterAdmin(admin, tp, nil)
+
+	_, err := traced.ListConsumerGroups()
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.True(t, codesError(spans[0]))
+}
+
+func TestNewTracedClient_nilTracerProviderReturnsUnwrapped(t *testing.T) {
+	client := newMockClient()
+	assert.Same(t, sarama.Client(client), newTracedClient(client, nil, nil))
+}
+
+func codesError(span sdktrace.ReadOnlySpan) bool {
+	return fmt.Sprint(span.Status().Code) == "Error"
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/lokireceiver"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const protocolsFieldName = "protocols"
+
+var errMissingProtocols = errors.New("must specify at least one protocol when using the Loki receiver")
+
+// Protocols lists the protocols the Loki receiver accepts push requests
+// over. At least one of GRPC or HTTP must be configured.
+type Protocols struct {
+	GRPC *configgrpc.GRPCServerSettings `mapstructure:"grpc"`
+	HTTP *confighttp.HTTPServerSettings `mapstructure:"http"`
+}
+
+// LabelsConfig controls how Loki stream labels and per-entry structured
+// metadata are mapped onto the OTel Resource/LogRecord produced for each
+// pushed entry.
+type LabelsConfig struct {
+	// ResourceAttributes lists the Loki stream labels to copy onto the
+	// resulting LogRecord's Resource attributes.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// LogAttributes lists the Loki stream labels to copy onto the resulting
+	// LogRecord's own attributes.
+	LogAttributes []string `mapstructure:"log_attributes"`
+
+	// StructuredMetadata, when true, copies each entry's Loki structured
+	// metadata key/values into a "structured_metadata" map attribute on the
+	// LogRecord. Structured metadata is only available on entries pushed
+	// over the JSON push format.
+	StructuredMetadata bool `mapstructure:"structured_metadata"`
+}
+
+// Config defines configuration for the Loki receiver.
+type Config struct {
+	Protocols Protocols `mapstructure:"protocols"`
+
+	// KeepTimestamp uses the timestamp supplied by the Loki client as the
+	// LogRecord timestamp. When false, the LogRecord timestamp is set to the
+	// time the entry was received instead.
+	KeepTimestamp bool `mapstructure:"keep_timestamp"`
+
+	// Labels configures how Loki stream labels and structured metadata are
+	// mapped onto the resulting OTel logs.
+	Labels LabelsConfig `mapstructure:"labels"`
+}
+
+var (
+	_ component.Config    = (*Config)(nil)
+	_ confmap.Unmarshaler = (*Config)(nil)
+)
+
+// Unmarshal a confmap.Conf into cfg, initializing each protocol the user
+// enables under "protocols" with that protocol's own defaults before
+// applying any user-supplied overrides on top of them.
+func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
+	if conf.IsSet(protocolsFieldName + "::grpc") {
+		cfg.Protocols.GRPC = defaultGRPCSettings()
+	}
+	if conf.IsSet(protocolsFieldName + "::http") {
+		cfg.Protocols.HTTP = defaultHTTPSettings()
+	}
+	return conf.Unmarshal(cfg, confmap.WithErrorUnused())
+}
+
+// Validate checks the receiver configuration is self consistent.
+func (cfg *Config) Validate() error {
+	if cfg.Protocols.GRPC == nil && cfg.Protocols.HTTP == nil {
+		return errMissingProtocols
+	}
+	return nil
+}
+
+func defaultGRPCSettings() *configgrpc.GRPCServerSettings {
+	return &configgrpc.GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "0.0.0.0:3600",
+			Transport: "tcp",
+		},
+	}
+}
+
+func defaultHTTPSettings() *confighttp.HTTPServerSettings {
+	return &confighttp.HTTPServerSettings{
+		Endpoint: "0.0.0.0:3500",
+	}
+}
@@ -70,6 +70,21 @@ func TestLoadConfig(t *testing.T) {
 				KeepTimestamp: true,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "labels"),
+			expected: &Config{
+				Protocols: Protocols{
+					HTTP: &confighttp.HTTPServerSettings{
+						Endpoint: "0.0.0.0:3500",
+					},
+				},
+				Labels: LabelsConfig{
+					ResourceAttributes: []string{"service.name", "service.namespace"},
+					LogAttributes:      []string{"level"},
+					StructuredMetadata: true,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -128,6 +143,10 @@ func TestConfigWithUnknownKeysConfig(t *testing.T) {
 			id:  component.NewIDWithName(typeStr, "extra_keys"),
 			err: "'' has invalid keys: foo",
 		},
+		{
+			id:  component.NewIDWithName(typeStr, "labels_extra_keys"),
+			err: "has invalid keys: foo",
+		},
 	}
 
 	for _, tt := range tests {
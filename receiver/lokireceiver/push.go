@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/lokireceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// lokiEntry is one decoded line of a Loki stream, along with any structured
+// metadata attached to it.
+type lokiEntry struct {
+	Timestamp          time.Time
+	Line               string
+	StructuredMetadata map[string]string
+}
+
+// lokiStream is a Loki stream: a set of labels shared by every entry in it.
+type lokiStream struct {
+	Labels  map[string]string
+	Entries []lokiEntry
+}
+
+// decodeJSONPushRequest parses a Loki push request sent with
+// Content-Type: application/json, i.e. a body shaped like:
+//
+//	{"streams": [{"stream": {"job": "..."}, "values": [["<unix nanos>", "<line>", {"k": "v"}]]}]}
+//
+// the per-entry structured metadata object is optional.
+func decodeJSONPushRequest(body io.Reader) ([]lokiStream, error) {
+	var raw struct {
+		Streams []struct {
+			Stream map[string]string   `json:"stream"`
+			Values [][]json.RawMessage `json:"values"`
+		} `json:"streams"`
+	}
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode Loki JSON push payload: %w", err)
+	}
+
+	streams := make([]lokiStream, 0, len(raw.Streams))
+	for _, s := range raw.Streams {
+		stream := lokiStream{Labels: s.Stream, Entries: make([]lokiEntry, 0, len(s.Values))}
+		for _, value := range s.Values {
+			entry, err := decodeJSONEntry(value)
+			if err != nil {
+				return nil, err
+			}
+			stream.Entries = append(stream.Entries, entry)
+		}
+		streams = append(streams, stream)
+	}
+	return streams, nil
+}
+
+func decodeJSONEntry(value []json.RawMessage) (lokiEntry, error) {
+	if len(value) < 2 {
+		return lokiEntry{}, fmt.Errorf("malformed Loki stream entry: want at least [timestamp, line], got %d fields", len(value))
+	}
+
+	var tsStr, line string
+	if err := json.Unmarshal(value[0], &tsStr); err != nil {
+		return lokiEntry{}, fmt.Errorf("malformed Loki entry timestamp: %w", err)
+	}
+	if err := json.Unmarshal(value[1], &line); err != nil {
+		return lokiEntry{}, fmt.Errorf("malformed Loki entry line: %w", err)
+	}
+	nanos, err := strconv.ParseInt(tsStr, 10, 64)
+	if err != nil {
+		return lokiEntry{}, fmt.Errorf("malformed Loki entry timestamp %q: %w", tsStr, err)
+	}
+
+	entry := lokiEntry{Timestamp: time.Unix(0, nanos), Line: line}
+	if len(value) > 2 {
+		if err := json.Unmarshal(value[2], &entry.StructuredMetadata); err != nil {
+			return lokiEntry{}, fmt.Errorf("malformed Loki entry structured metadata: %w", err)
+		}
+	}
+	return entry, nil
+}
+
+// streamsToLogs converts decoded Loki streams into plog.Logs, one
+// ResourceLogs per stream, mapping stream labels and structured metadata
+// onto the resulting LogRecords according to cfg.Labels.
+func streamsToLogs(streams []lokiStream, cfg *Config) plog.Logs {
+	logs := plog.NewLogs()
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	for _, stream := range streams {
+		rl := logs.ResourceLogs().AppendEmpty()
+		resourceAttrs := rl.Resource().Attributes()
+		for _, key := range cfg.Labels.ResourceAttributes {
+			if v, ok := stream.Labels[key]; ok {
+				resourceAttrs.PutStr(key, v)
+			}
+		}
+
+		sl := rl.ScopeLogs().AppendEmpty()
+		for _, entry := range stream.Entries {
+			lr := sl.LogRecords().AppendEmpty()
+			lr.Body().SetStr(entry.Line)
+			lr.SetObservedTimestamp(now)
+			if cfg.KeepTimestamp {
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(entry.Timestamp))
+			} else {
+				lr.SetTimestamp(now)
+			}
+
+			for _, key := range cfg.Labels.LogAttributes {
+				if v, ok := stream.Labels[key]; ok {
+					lr.Attributes().PutStr(key, v)
+				}
+			}
+
+			if cfg.Labels.StructuredMetadata && len(entry.StructuredMetadata) > 0 {
+				md := lr.Attributes().PutEmptyMap("structured_metadata")
+				for k, v := range entry.StructuredMetadata {
+					md.PutStr(k, v)
+				}
+			}
+		}
+	}
+
+	return logs
+}
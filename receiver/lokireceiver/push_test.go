@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSONPushRequest(t *testing.T) {
+	body := `{"streams": [{"stream": {"job": "varlogs"}, "values": [["1700000000000000000", "hello"]]}]}`
+
+	streams, err := decodeJSONPushRequest(strings.NewReader(body))
+	require.NoError(t, err)
+	require.Len(t, streams, 1)
+	assert.Equal(t, map[string]string{"job": "varlogs"}, streams[0].Labels)
+	require.Len(t, streams[0].Entries, 1)
+	assert.Equal(t, "hello", streams[0].Entries[0].Line)
+	assert.Equal(t, int64(1700000000000000000), streams[0].Entries[0].Timestamp.UnixNano())
+}
+
+func TestDecodeJSONPushRequest_structuredMetadata(t *testing.T) {
+	body := `{"streams": [{"stream": {"job": "varlogs"}, "values": [["1700000000000000000", "hello", {"trace_id": "abc123"}]]}]}`
+
+	streams, err := decodeJSONPushRequest(strings.NewReader(body))
+	require.NoError(t, err)
+	require.Len(t, streams[0].Entries, 1)
+	assert.Equal(t, map[string]string{"trace_id": "abc123"}, streams[0].Entries[0].StructuredMetadata)
+}
+
+func TestDecodeJSONPushRequest_malformedEntry(t *testing.T) {
+	body := `{"streams": [{"stream": {}, "values": [["only one field"]]}]}`
+
+	_, err := decodeJSONPushRequest(strings.NewReader(body))
+	assert.Error(t, err)
+}
+
+func TestDecodeJSONPushRequest_invalidJSON(t *testing.T) {
+	_, err := decodeJSONPushRequest(strings.NewReader("not json"))
+	assert.Error(t, err)
+}
+
+func TestStreamsToLogs_mapsLabelsAndStructuredMetadata(t *testing.T) {
+	cfg := &Config{
+		Labels: LabelsConfig{
+			ResourceAttributes: []string{"service.name"},
+			LogAttributes:      []string{"level"},
+			StructuredMetadata: true,
+		},
+	}
+	streams := []lokiStream{
+		{
+			Labels: map[string]string{"service.name": "checkout", "level": "info"},
+			Entries: []lokiEntry{
+				{Line: "hello", StructuredMetadata: map[string]string{"trace_id": "abc123"}},
+			},
+		},
+	}
+
+	logs := streamsToLogs(streams, cfg)
+	require.Equal(t, 1, logs.ResourceLogs().Len())
+	rl := logs.ResourceLogs().At(0)
+
+	resourceServiceName, ok := rl.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "checkout", resourceServiceName.Str())
+
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "hello", lr.Body().Str())
+
+	level, ok := lr.Attributes().Get("level")
+	require.True(t, ok)
+	assert.Equal(t, "info", level.Str())
+
+	md, ok := lr.Attributes().Get("structured_metadata")
+	require.True(t, ok)
+	traceID, ok := md.Map().Get("trace_id")
+	require.True(t, ok)
+	assert.Equal(t, "abc123", traceID.Str())
+}
+
+func TestStreamsToLogs_structuredMetadataDisabledByDefault(t *testing.T) {
+	cfg := &Config{}
+	streams := []lokiStream{
+		{Entries: []lokiEntry{{Line: "hello", StructuredMetadata: map[string]string{"trace_id": "abc123"}}}},
+	}
+
+	logs := streamsToLogs(streams, cfg)
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	_, ok := lr.Attributes().Get("structured_metadata")
+	assert.False(t, ok)
+}
+
+func TestStreamsToLogs_keepTimestamp(t *testing.T) {
+	entryTime := int64(1700000000000000000)
+	streams, err := decodeJSONPushRequest(strings.NewReader(
+		`{"streams": [{"stream": {}, "values": [["1700000000000000000", "hello"]]}]}`))
+	require.NoError(t, err)
+
+	logs := streamsToLogs(streams, &Config{KeepTimestamp: true})
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, entryTime, int64(lr.Timestamp()))
+}
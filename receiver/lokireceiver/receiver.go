@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/lokireceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+const pushPath = "/loki/api/v1/push"
+
+// lokiReceiver accepts Loki push requests over HTTP and forwards the decoded
+// entries to the next consumer as logs.
+//
+// Loki's native push format is a gzip/snappy-compressed protobuf
+// (logproto.PushRequest); decoding it requires the generated logproto
+// bindings, which this module does not vendor, so only the JSON push format
+// (Content-Type: application/json) is currently accepted. A request sent
+// with any other Content-Type is rejected with a 415 explaining why.
+type lokiReceiver struct {
+	cfg      *Config
+	consumer consumer.Logs
+	settings receiver.CreateSettings
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+func newLokiReceiver(cfg *Config, nextConsumer consumer.Logs, settings receiver.CreateSettings) (*lokiReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	return &lokiReceiver{cfg: cfg, consumer: nextConsumer, settings: settings}, nil
+}
+
+func (r *lokiReceiver) Start(_ context.Context, host component.Host) error {
+	if r.cfg.Protocols.GRPC != nil {
+		r.settings.Logger.Warn("Loki receiver's grpc protocol is configured but not implemented in this build; the receiver only accepts pushes over its http protocol")
+	}
+	if r.cfg.Protocols.HTTP == nil {
+		if r.cfg.Protocols.GRPC != nil {
+			return errors.New("loki receiver: grpc protocol is not implemented in this build, configure the http protocol instead")
+		}
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(pushPath, r.handlePush)
+
+	httpServer, err := r.cfg.Protocols.HTTP.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return fmt.Errorf("failed to create Loki receiver http server: %w", err)
+	}
+	listener, err := r.cfg.Protocols.HTTP.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind Loki receiver http server: %w", err)
+	}
+	r.server = httpServer
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if err := r.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			r.settings.Logger.Error("Loki receiver http server failed", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (r *lokiReceiver) Shutdown(ctx context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Shutdown(ctx)
+	r.shutdownWG.Wait()
+	return err
+}
+
+func (r *lokiReceiver) handlePush(w http.ResponseWriter, req *http.Request) {
+	contentType := req.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "application/json") {
+		http.Error(w, fmt.Sprintf(
+			"unsupported Content-Type %q: this build only accepts Loki push requests sent as application/json", contentType),
+			http.StatusUnsupportedMediaType)
+		return
+	}
+
+	streams, err := decodeJSONPushRequest(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logs := streamsToLogs(streams, r.cfg)
+	if err := r.consumer.ConsumeLogs(req.Context(), logs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestNewLokiReceiver_nilConsumer(t *testing.T) {
+	_, err := newLokiReceiver(&Config{}, nil, receivertest.NewNopCreateSettings())
+	assert.Error(t, err)
+}
+
+func TestLokiReceiver_handlePush_rejectsUnknownContentType(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r, err := newLokiReceiver(&Config{}, sink, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, pushPath, strings.NewReader("irrelevant"))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	rec := httptest.NewRecorder()
+
+	r.handlePush(rec, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestLokiReceiver_handlePush_acceptsJSON(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r, err := newLokiReceiver(&Config{}, sink, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+
+	body := `{"streams": [{"stream": {"job": "varlogs"}, "values": [["1700000000000000000", "hello"]]}]}`
+	req := httptest.NewRequest(http.MethodPost, pushPath, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	r.handlePush(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, 1, sink.AllLogs()[0].LogRecordCount())
+}
+
+func TestLokiReceiver_handlePush_badJSONReturns400(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r, err := newLokiReceiver(&Config{}, sink, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, pushPath, strings.NewReader("not json"))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	r.handlePush(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestLokiReceiver_StartShutdown_noProtocolsIsNoop(t *testing.T) {
+	r, err := newLokiReceiver(&Config{}, new(consumertest.LogsSink), receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestLokiReceiver_Start_grpcOnlyIsUnimplemented(t *testing.T) {
+	cfg := &Config{Protocols: Protocols{GRPC: defaultGRPCSettings()}}
+	r, err := newLokiReceiver(cfg, new(consumertest.LogsSink), receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+
+	assert.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
+}